@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+
+	gpc "github.com/restuwahyu13/go-playground-converter"
+
+	config "github.com/restuwahyu13/go-fast-search/configs"
+	service "github.com/restuwahyu13/go-fast-search/domain/services"
+	con "github.com/restuwahyu13/go-fast-search/internal/infrastructure/connections"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+var (
+	err     error
+	env     dto.Request[dto.Environtment]
+	env_res *opt.Environtment
+)
+
+const usage = `Usage: account <command> [flags]
+
+Commands:
+  create   create login account, flags: -username -password -role
+  role     change role of an account and revoke its sessions, flags: -id -role
+  revoke   revoke every session of an account, flags: -id
+`
+
+func init() {
+	runtime.GOMAXPROCS(runtime.NumCPU() / 2)
+	transform := helper.NewTransform()
+
+	env_res, err = config.NewEnvirontment(".env", ".", "env")
+	if err != nil {
+		pkg.Logrus(cons.FATAL, err)
+		return
+	}
+
+	if env_res != nil {
+		if err := transform.ResToReq(env_res, &env.Config); err != nil {
+			pkg.Logrus(cons.FATAL, err)
+			return
+		}
+	}
+}
+
+/**
+* Manage accounts allowed to login into the api
+*
+* go run ./cmd/account create -username=admin -password=<password> -role=admin
+* go run ./cmd/account revoke -id=<account id>
+ */
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+
+	db, err := con.SqlConnection(ctx, env)
+	if err != nil {
+		pkg.Logrus(cons.FATAL, err)
+		return
+	}
+	defer db.Close()
+
+	rds, err := con.RedisConnection(env)
+	if err != nil {
+		pkg.Logrus(cons.FATAL, err)
+		return
+	}
+	defer rds.Close()
+
+	authService := service.NewAuthService(dto.ServiceOptions{ENV: env, DB: db, RDS: rds})
+
+	res, err := command(ctx, authService, os.Args[1], os.Args[2:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	output, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		pkg.Logrus(cons.FATAL, err)
+		return
+	}
+
+	fmt.Println(string(output))
+
+	if res.StatCode >= http.StatusBadRequest {
+		os.Exit(1)
+	}
+}
+
+func command(ctx context.Context, authService inf.IAuthService, name string, args []string) (opt.Response, error) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+
+	username := flags.String("username", "", "account username")
+	password := flags.String("password", "", "account password, 8 until 72 characters")
+	id := flags.String("id", "", "account id")
+	role := flags.String("role", cons.ROLE_SUPPORT, "account role, admin or support")
+
+	if err := flags.Parse(args); err != nil {
+		return opt.Response{}, err
+	}
+
+	switch name {
+
+	case "create":
+		req := dto.Request[dto.CreateAccountDTO]{}
+		req.Body.Username = *username
+		req.Body.Password = *password
+		req.Body.Role = *role
+
+		errors, err := gpc.Validator(req.Body)
+		if err != nil {
+			return opt.Response{}, err
+		}
+
+		if errors != nil {
+			return opt.Response{StatCode: http.StatusUnprocessableEntity, Errors: errors.Errors}, nil
+		}
+
+		return authService.CreateAccount(ctx, req), nil
+
+	case "role":
+		req := dto.Request[dto.UpdateAccountRoleDTO]{}
+		req.Body.ID = *id
+		req.Body.Role = *role
+
+		errors, err := gpc.Validator(req.Body)
+		if err != nil {
+			return opt.Response{}, err
+		}
+
+		if errors != nil {
+			return opt.Response{StatCode: http.StatusUnprocessableEntity, Errors: errors.Errors}, nil
+		}
+
+		return authService.UpdateAccountRole(ctx, req), nil
+
+	case "revoke":
+		req := dto.Request[dto.RevokeSessionsDTO]{}
+		req.Param.ID = *id
+
+		errors, err := gpc.Validator(req.Param)
+		if err != nil {
+			return opt.Response{}, err
+		}
+
+		if errors != nil {
+			return opt.Response{StatCode: http.StatusUnprocessableEntity, Errors: errors.Errors}, nil
+		}
+
+		return authService.RevokeSessions(ctx, req), nil
+
+	default:
+		return opt.Response{}, fmt.Errorf("unknown command: %s", name)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/wagslane/go-rabbitmq"
 
 	config "github.com/restuwahyu13/go-fast-search/configs"
+	mw "github.com/restuwahyu13/go-fast-search/internal/adapters/http/middlewares"
 	con "github.com/restuwahyu13/go-fast-search/internal/infrastructure/connections"
 	module "github.com/restuwahyu13/go-fast-search/internal/modules"
 	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
@@ -89,12 +90,22 @@ func main() {
 	}
 	defer rds.Close()
 
-	amqp, err := con.RabbitConnection(env)
+	amqp, err := con.BrokerConnection(env)
 	if err != nil {
 		pkg.Logrus(cons.FATAL, err)
 		return
 	}
-	defer amqp.Close()
+
+	if amqp != nil {
+		defer amqp.Close()
+
+		publisher, err := pkg.NewPublisher(amqp, env)
+		if err != nil {
+			pkg.Logrus(cons.FATAL, err)
+			return
+		}
+		defer publisher.Close()
+	}
 
 	mls := con.MeiliSearchConnection(env)
 	if !mls.IsHealthy() {
@@ -137,8 +148,9 @@ func (a Api) Middleware() {
 		a.ROUTER.Use(middleware.Logger)
 	}
 
+	a.ROUTER.Use(middleware.RequestID)
 	a.ROUTER.Use(middleware.Recoverer)
-	a.ROUTER.Use(middleware.RealIP)
+	a.ROUTER.Use(mw.RealIP(&a.ENV.Config))
 	a.ROUTER.Use(middleware.NoCache)
 	a.ROUTER.Use(middleware.GetHead)
 	a.ROUTER.Use(middleware.Compress(zlib.BestCompression))
@@ -146,7 +158,7 @@ func (a Api) Middleware() {
 	a.ROUTER.Use(cors.Handler(cors.Options{
 		AllowedOrigins:     []string{"*"},
 		AllowedMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
-		AllowedHeaders:     []string{"Accept", "Content-Type", "Authorization"},
+		AllowedHeaders:     []string{"Accept", "Content-Type", "Authorization", cons.API_KEY_HEADER},
 		AllowCredentials:   true,
 		OptionsPassthrough: true,
 		MaxAge:             900,
@@ -176,6 +188,15 @@ func (a Api) Middleware() {
 }
 
 func (a Api) Module() {
+	module.NewAuthModule[inf.IAuthService](dto.ModuleOptions{
+		ENV:    a.ENV,
+		DB:     a.DB,
+		RDS:    a.RDS,
+		AMQP:   a.AMQP,
+		MLS:    a.MLS,
+		ROUTER: a.ROUTER,
+	})
+
 	module.NewUsersModule[inf.IUsersService](dto.ModuleOptions{
 		ENV:    a.ENV,
 		DB:     a.DB,
@@ -184,6 +205,42 @@ func (a Api) Module() {
 		MLS:    a.MLS,
 		ROUTER: a.ROUTER,
 	})
+
+	module.NewApiKeysModule[inf.IApiKeysService](dto.ModuleOptions{
+		ENV:    a.ENV,
+		DB:     a.DB,
+		RDS:    a.RDS,
+		AMQP:   a.AMQP,
+		MLS:    a.MLS,
+		ROUTER: a.ROUTER,
+	})
+
+	module.NewLeasesModule[inf.ILeasesService](dto.ModuleOptions{
+		ENV:    a.ENV,
+		DB:     a.DB,
+		RDS:    a.RDS,
+		AMQP:   a.AMQP,
+		MLS:    a.MLS,
+		ROUTER: a.ROUTER,
+	})
+
+	module.NewDeadLettersModule[inf.IDeadLettersService](dto.ModuleOptions{
+		ENV:    a.ENV,
+		DB:     a.DB,
+		RDS:    a.RDS,
+		AMQP:   a.AMQP,
+		MLS:    a.MLS,
+		ROUTER: a.ROUTER,
+	})
+
+	module.NewAuditLogsModule[inf.IAuditLogsService](dto.ModuleOptions{
+		ENV:    a.ENV,
+		DB:     a.DB,
+		RDS:    a.RDS,
+		AMQP:   a.AMQP,
+		MLS:    a.MLS,
+		ROUTER: a.ROUTER,
+	})
 }
 
 func (a Api) Listener() {
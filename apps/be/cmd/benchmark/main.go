@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+
+	config "github.com/restuwahyu13/go-fast-search/configs"
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	repo "github.com/restuwahyu13/go-fast-search/domain/repositories"
+	con "github.com/restuwahyu13/go-fast-search/internal/infrastructure/connections"
+	scheduler "github.com/restuwahyu13/go-fast-search/internal/infrastructure/schedulers"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+var (
+	err     error
+	env     dto.Request[dto.Environtment]
+	env_res *opt.Environtment
+)
+
+var (
+	rows    = flag.Int("rows", 100000, "total users row seeded into postgres")
+	batch   = flag.Int("batch", 0, "scheduler batch size, default from SCHEDULER_BATCH_SIZE")
+	cleanup = flag.Bool("cleanup", true, "remove seeded users from postgres and meilisearch after benchmark")
+)
+
+func init() {
+	runtime.GOMAXPROCS(runtime.NumCPU() / 2)
+	transform := helper.NewTransform()
+
+	env_res, err = config.NewEnvirontment(".env", ".", "env")
+	if err != nil {
+		pkg.Logrus(cons.FATAL, err)
+		return
+	}
+
+	if env_res != nil {
+		if err := transform.ResToReq(env_res, &env.Config); err != nil {
+			pkg.Logrus(cons.FATAL, err)
+			return
+		}
+	}
+}
+
+/**
+* Benchmark search scheduler sync throughput, seed users into postgres then drain it to meilisearch,
+* disable query debug log with BUNDEBUG=0 for accurate result
+*
+* BUNDEBUG=0 go run ./cmd/benchmark -rows=100000 -batch=500
+ */
+func main() {
+	flag.Parse()
+
+	ctx := context.Background()
+
+	if *batch > 0 {
+		env.Config.SCHEDULER.BATCH_SIZE = *batch
+	}
+
+	db, err := con.SqlConnection(ctx, env)
+	if err != nil {
+		pkg.Logrus(cons.FATAL, err)
+		return
+	}
+	defer db.Close()
+
+	rds, err := con.RedisConnection(env)
+	if err != nil {
+		pkg.Logrus(cons.FATAL, err)
+		return
+	}
+	defer rds.Close()
+
+	mls := con.MeiliSearchConnection(env)
+	if !mls.IsHealthy() {
+		pkg.Logrus(cons.FATAL, errors.New("meilisearch is not healthy"))
+		return
+	}
+	defer mls.Close()
+
+	startAt := time.Now().Add(-time.Minute)
+
+	ids, err := seed(ctx, db, *rows)
+	if err != nil {
+		pkg.Logrus(cons.FATAL, err)
+		return
+	}
+
+	if *cleanup {
+		defer func() {
+			if err := clean(ctx, db, repo.NewUsersMeilisearchRepositorie(ctx, mls), ids); err != nil {
+				pkg.Logrus(cons.ERROR, err)
+			}
+		}()
+	}
+
+	if err := rds.Set(ctx, "WORKER:SEARCH:CDC", startAt.Format(time.RFC3339), 0).Err(); err != nil {
+		pkg.Logrus(cons.FATAL, err)
+		return
+	}
+
+	if err := rds.Del(ctx, "SCHEDULER:SEARCH:BREAK").Err(); err != nil {
+		pkg.Logrus(cons.FATAL, err)
+		return
+	}
+
+	searchScheduler := scheduler.NewSearchScheduler(dto.SchedulerOptions{
+		CTX: ctx,
+		ENV: env,
+		DB:  db,
+		RDS: rds,
+		MLS: mls,
+	})
+
+	elapsed := time.Now()
+
+	// benchmark run without a lease, token 0 only pass rows no holder has synced yet
+	if err := searchScheduler.SearchHandler(ctx, func() (int64, error) { return 0, nil }); err != nil {
+		pkg.Logrus(cons.FATAL, err)
+		return
+	}
+
+	duration := time.Since(elapsed)
+
+	synced, err := db.NewSelect().Model((*entitie.UsersEntitie)(nil)).Where("id = ANY(?) AND is_sync = ?", pgdialect.Array(ids), cons.TRUE).Count(ctx)
+	if err != nil {
+		pkg.Logrus(cons.FATAL, err)
+		return
+	}
+
+	pkg.Logrus(cons.INFO, "Benchmark synced %d of %d rows in %s, throughput %.2f rows/s", synced, len(ids), duration, float64(synced)/duration.Seconds())
+}
+
+func seed(ctx context.Context, db *bun.DB, total int) ([]string, error) {
+	chunk := 1000
+	ids := make([]string, 0, total)
+
+	for offset := 0; offset < total; offset += chunk {
+		usersEntities := []entitie.UsersEntitie{}
+
+		for i := offset; i < offset+chunk && i < total; i++ {
+			id := uuid.NewString()
+
+			usersEntities = append(usersEntities, entitie.UsersEntitie{
+				ID:          id,
+				Name:        fmt.Sprintf("benchmark user %d", i),
+				Email:       fmt.Sprintf("benchmark+%s@example.com", id),
+				Phone:       fmt.Sprintf("+62 812 %07d", i),
+				DateOfBirth: "1990-01-01T00:00:00.000Z",
+				Age:         "35",
+				Address:     fmt.Sprintf("Benchmark street %d", i),
+				City:        "Jakarta",
+				State:       "DKI Jakarta",
+				Direction:   "North",
+				Country:     "Indonesia",
+				PostalCode:  "10110",
+				CreatedAt:   time.Now(),
+			})
+
+			ids = append(ids, id)
+		}
+
+		if _, err := db.NewInsert().Model(&usersEntities).Exec(ctx); err != nil {
+			return ids, err
+		}
+	}
+
+	pkg.Logrus(cons.INFO, "Seeded total data %d into postgres", len(ids))
+	return ids, nil
+}
+
+func clean(ctx context.Context, db *bun.DB, usersDocRepositorie inf.IUsersMeiliSearchRepositorie, ids []string) error {
+	chunk := 1000
+
+	for offset := 0; offset < len(ids); offset += chunk {
+		end := min(offset+chunk, len(ids))
+
+		if err := usersDocRepositorie.BulkDestroy(ids[offset:end]...); err != nil {
+			return err
+		}
+	}
+
+	if _, err := db.NewDelete().Model((*entitie.UsersEntitie)(nil)).Where("id = ANY(?)", pgdialect.Array(ids)).Exec(ctx); err != nil {
+		return err
+	}
+
+	pkg.Logrus(cons.INFO, "Cleanup total data %d from postgres and meilisearch", len(ids))
+	return nil
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+
+	config "github.com/restuwahyu13/go-fast-search/configs"
+	service "github.com/restuwahyu13/go-fast-search/domain/services"
+	con "github.com/restuwahyu13/go-fast-search/internal/infrastructure/connections"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+var (
+	err     error
+	env     dto.Request[dto.Environtment]
+	env_res *opt.Environtment
+)
+
+const usage = `Usage: dlq <command> [flags]
+
+Commands:
+  list     list dead letters, flags: -document-id -status -limit -page
+  show     show one dead letter, flags: -id
+  replay   replay parked dead letters, flags: -id | -document-id | -all
+  discard  discard parked or retrying dead letters, flags: -id | -document-id | -all
+`
+
+func init() {
+	runtime.GOMAXPROCS(runtime.NumCPU() / 2)
+	transform := helper.NewTransform()
+
+	env_res, err = config.NewEnvirontment(".env", ".", "env")
+	if err != nil {
+		pkg.Logrus(cons.FATAL, err)
+		return
+	}
+
+	if env_res != nil {
+		if err := transform.ResToReq(env_res, &env.Config); err != nil {
+			pkg.Logrus(cons.FATAL, err)
+			return
+		}
+	}
+}
+
+/**
+* Inspect and replay dead lettered messages mirrored into postgres
+*
+* go run ./cmd/dlq list -status=parked -document-id=<id>
+* go run ./cmd/dlq replay -all
+ */
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+
+	db, err := con.SqlConnection(ctx, env)
+	if err != nil {
+		pkg.Logrus(cons.FATAL, err)
+		return
+	}
+	defer db.Close()
+
+	rds, err := con.RedisConnection(env)
+	if err != nil {
+		pkg.Logrus(cons.FATAL, err)
+		return
+	}
+	defer rds.Close()
+
+	amqp, err := con.BrokerConnection(env)
+	if err != nil {
+		pkg.Logrus(cons.FATAL, err)
+		return
+	}
+
+	if amqp != nil {
+		defer amqp.Close()
+
+		publisher, err := pkg.NewPublisher(amqp, env)
+		if err != nil {
+			pkg.Logrus(cons.FATAL, err)
+			return
+		}
+		defer publisher.Close()
+	}
+
+	deadLettersService := service.NewDeadLettersService(dto.ServiceOptions{ENV: env, DB: db, RDS: rds, AMQP: amqp})
+
+	res, err := command(ctx, deadLettersService, os.Args[1], os.Args[2:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	output, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		pkg.Logrus(cons.FATAL, err)
+		return
+	}
+
+	fmt.Println(string(output))
+
+	if res.StatCode >= http.StatusBadRequest {
+		os.Exit(1)
+	}
+}
+
+func command(ctx context.Context, deadLettersService inf.IDeadLettersService, name string, args []string) (opt.Response, error) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+
+	id := flags.String("id", "", "dead letter id")
+	documentID := flags.String("document-id", "", "filter dead letters by document id")
+	status := flags.String("status", "", "filter dead letters by status, retrying, parked, replayed or discarded")
+	limit := flags.Int64("limit", 10, "total dead letters per page")
+	page := flags.Int64("page", 1, "page number")
+	all := flags.Bool("all", false, "apply to every matching dead letter")
+
+	if err := flags.Parse(args); err != nil {
+		return opt.Response{}, err
+	}
+
+	switch name {
+
+	case "list":
+		req := dto.Request[dto.ListDeadLettersDTO]{}
+		req.Query.DocumentID = *documentID
+		req.Query.Status = *status
+		req.Query.Limit = *limit
+		req.Query.Page = *page
+
+		return deadLettersService.FindAllDeadLetters(ctx, req), nil
+
+	case "show":
+		if *id == "" {
+			return opt.Response{}, fmt.Errorf("%s: -id is required", name)
+		}
+
+		req := dto.Request[dto.DeadLetterIDDTO]{}
+		req.Param.ID = *id
+
+		return deadLettersService.FindDeadLetter(ctx, req), nil
+
+	case "replay", "discard":
+		if *id != "" {
+			req := dto.Request[dto.DeadLetterIDDTO]{}
+			req.Param.ID = *id
+
+			if name == "replay" {
+				return deadLettersService.ReplayDeadLetter(ctx, req), nil
+			}
+
+			return deadLettersService.DiscardDeadLetter(ctx, req), nil
+		}
+
+		if *documentID == "" && !*all {
+			return opt.Response{}, fmt.Errorf("%s: one of -id, -document-id or -all is required", name)
+		}
+
+		req := dto.Request[dto.DeadLettersFilterDTO]{}
+		req.Query.DocumentID = *documentID
+
+		if name == "replay" {
+			return deadLettersService.ReplayAllDeadLetters(ctx, req), nil
+		}
+
+		return deadLettersService.DiscardAllDeadLetters(ctx, req), nil
+
+	default:
+		return opt.Response{}, fmt.Errorf("unknown command: %s", name)
+	}
+}
@@ -3,42 +3,49 @@ package main
 import (
 	"context"
 	"errors"
+	"net/http"
 	"os"
 	"os/signal"
 	"runtime"
-	"sync"
 	"syscall"
 	"time"
 
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
 	"github.com/meilisearch/meilisearch-go"
 	"github.com/redis/go-redis/v9"
 	"github.com/uptrace/bun"
 
 	config "github.com/restuwahyu13/go-fast-search/configs"
+	mw "github.com/restuwahyu13/go-fast-search/internal/adapters/http/middlewares"
 	con "github.com/restuwahyu13/go-fast-search/internal/infrastructure/connections"
 	scheduler "github.com/restuwahyu13/go-fast-search/internal/infrastructure/schedulers"
+	module "github.com/restuwahyu13/go-fast-search/internal/modules"
 	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
 	"github.com/restuwahyu13/go-fast-search/shared/dto"
 	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
 	opt "github.com/restuwahyu13/go-fast-search/shared/output"
 	"github.com/restuwahyu13/go-fast-search/shared/pkg"
 )
 
 type (
 	IScheduler interface {
+		Middleware()
+		Module()
 		Listener()
 	}
 
 	Scheduler struct {
-		CTX context.Context
-		ENV dto.Request[dto.Environtment]
-		DB  *bun.DB
-		RDS *redis.Client
-		MLS meilisearch.ServiceManager
-	}
-
-	syncOnce struct {
-		searchScheduler *sync.Once
+		CTX     context.Context
+		CANCEL  context.CancelFunc
+		ENV     dto.Request[dto.Environtment]
+		ENV_RES *opt.Environtment
+		ROUTER  *chi.Mux
+		DB      *bun.DB
+		RDS     *redis.Client
+		MLS     meilisearch.ServiceManager
+		JOBS    inf.IJobRegistry
 	}
 )
 
@@ -67,7 +74,10 @@ func init() {
 }
 
 func main() {
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	router := chi.NewRouter()
 
 	db, err := con.SqlConnection(ctx, env)
 	if err != nil {
@@ -90,88 +100,143 @@ func main() {
 	}
 	defer mls.Close()
 
-	req := dto.Request[Scheduler]{}
-	req.Option = Scheduler{
+	jobs := scheduler.NewJobRegistry(dto.SchedulerOptions{
 		CTX: ctx,
 		ENV: env,
 		DB:  db,
 		RDS: rds,
 		MLS: mls,
+	})
+
+	req := dto.Request[Scheduler]{}
+	req.Option = Scheduler{
+		CTX:     ctx,
+		CANCEL:  cancel,
+		ENV:     env,
+		ENV_RES: env_res,
+		ROUTER:  router,
+		DB:      db,
+		RDS:     rds,
+		MLS:     mls,
+		JOBS:    jobs,
 	}
 
 	app := NewScheduler(req)
+	app.Middleware()
+	app.Module()
 	app.Listener()
 }
 
 func NewScheduler(req dto.Request[Scheduler]) IScheduler {
 	return Scheduler{
-		CTX: req.Option.CTX,
-		ENV: req.Option.ENV,
-		DB:  req.Option.DB,
-		RDS: req.Option.RDS,
-		MLS: req.Option.MLS,
+		CTX:     req.Option.CTX,
+		CANCEL:  req.Option.CANCEL,
+		ENV:     req.Option.ENV,
+		ENV_RES: req.Option.ENV_RES,
+		ROUTER:  req.Option.ROUTER,
+		DB:      req.Option.DB,
+		RDS:     req.Option.RDS,
+		MLS:     req.Option.MLS,
+		JOBS:    req.Option.JOBS,
 	}
 }
 
-func (w Scheduler) scheduler(wg *sync.WaitGroup, rso syncOnce) {
-	defer wg.Done()
-
-	rso.searchScheduler.Do(func() {
-		scheduler.NewSearchScheduler(dto.SchedulerOptions{
-			CTX: w.CTX,
-			ENV: w.ENV,
-			DB:  w.DB,
-			RDS: w.RDS,
-			MLS: w.MLS,
-		}).SearchRun()
+func (w Scheduler) Middleware() {
+	w.ROUTER.Use(middleware.Recoverer)
+	w.ROUTER.Use(mw.RealIP(&w.ENV.Config))
+	w.ROUTER.Use(middleware.NoCache)
+
+	w.ROUTER.MethodNotAllowed(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		helper.Api(rw, r, opt.Response{
+			StatCode: http.StatusMethodNotAllowed,
+			ErrMsg:   "Route method not allowed",
+		})
+	}))
+
+	w.ROUTER.NotFound(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		helper.Api(rw, r, opt.Response{
+			StatCode: http.StatusNotFound,
+			ErrMsg:   "Route not found",
+		})
+	}))
+}
+
+func (w Scheduler) Module() {
+	module.NewJobsModule[inf.IJobsService](dto.RegistryModuleOptions[inf.IJobControl]{
+		ENV:      w.ENV,
+		RDS:      w.RDS,
+		REGISTRY: w.JOBS,
+		ROUTER:   w.ROUTER,
 	})
 }
 
-func (w Scheduler) register(wg *sync.WaitGroup) {
-	worker := runtime.NumCPU()
-	searchSchedulerOnce := new(sync.Once)
+/**
+* Internal server only for job control, keep the port private from public network
+ */
+func (w Scheduler) server() {
+	if w.ENV.Config.SCHEDULER.PORT == "" {
+		pkg.Logrus(cons.INFO, "Scheduler internal server is disabled, SCHEDULER_PORT is not set")
+		return
+	}
+
+	err := pkg.Graceful(w.ENV, func() opt.Graceful {
+		return opt.Graceful{HANDLER: w.ROUTER, ENV: w.ENV_RES, PORT: w.ENV.Config.SCHEDULER.PORT}
+	})
 
-	rso := syncOnce{
-		searchScheduler: searchSchedulerOnce,
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
 	}
+}
+
+func (w Scheduler) scheduler() {
+	jobs := scheduler.NewJobs(dto.SchedulerOptions{
+		CTX: w.CTX,
+		ENV: w.ENV,
+		DB:  w.DB,
+		RDS: w.RDS,
+		MLS: w.MLS,
+	})
 
-	for i := 1; i <= worker; i++ {
-		wg.Add(1)
-		go w.scheduler(wg, rso)
+	for _, job := range jobs {
+		if err := w.JOBS.Register(job); err != nil {
+			pkg.Logrus(cons.FATAL, err)
+			return
+		}
 	}
+
+	w.JOBS.Start()
 }
 
+/**
+* Shutdown stop scheduling new runs and wait the running jobs until the deadline, the context is
+* canceled afterward and the connections are closed by main once the listener return
+ */
 func (w Scheduler) Listener() {
-	wg := sync.WaitGroup{}
-	w.register(&wg)
+	w.scheduler()
 
-	ctx, cancel := context.WithCancel(w.CTX)
-	defer cancel()
+	go w.server()
 
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP, syscall.SIGQUIT, syscall.SIGALRM, syscall.SIGABRT, syscall.SIGUSR1)
 
-	for {
-		select {
-		case <-ch:
-			if w.ENV.Config.APP.ENV != cons.DEV {
-				time.Sleep(time.Second * 10)
-			} else {
-				time.Sleep(time.Second * 15)
-			}
-
-			wg.Wait()
-			os.Exit(0)
-			return
+	pkg.Logrus(cons.INFO, "Scheduler is running")
 
-		case <-ctx.Done():
-			wg.Wait()
-			return
+	select {
+	case sig := <-ch:
+		pkg.Logrus(cons.INFO, "Scheduler receive %s signal, shutting down", sig)
 
-		default:
-			wg.Wait()
-			time.Sleep(time.Second * 5)
-			pkg.Logrus(cons.INFO, "Scheduler is running")
-		}
+	case <-w.CTX.Done():
+	}
+
+	timeout := w.ENV.Config.SCHEDULER.SHUTDOWN_TIMEOUT
+	if timeout < 1 {
+		timeout = cons.JOB_SHUTDOWN_DEFAULT_TIMEOUT
+	}
+
+	if err := w.JOBS.Shutdown(time.Duration(time.Second * time.Duration(timeout))); err != nil {
+		pkg.Logrus(cons.ERROR, err)
 	}
+
+	w.CANCEL()
+	pkg.Logrus(cons.INFO, "Scheduler is stopped")
 }
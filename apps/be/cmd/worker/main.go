@@ -3,45 +3,51 @@ package main
 import (
 	"context"
 	"errors"
+	"net/http"
 	"os"
 	"os/signal"
 	"runtime"
-	"sync"
 	"syscall"
 	"time"
 
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
 	"github.com/meilisearch/meilisearch-go"
 	"github.com/redis/go-redis/v9"
 	"github.com/uptrace/bun"
 	"github.com/wagslane/go-rabbitmq"
 
 	config "github.com/restuwahyu13/go-fast-search/configs"
+	mw "github.com/restuwahyu13/go-fast-search/internal/adapters/http/middlewares"
 	con "github.com/restuwahyu13/go-fast-search/internal/infrastructure/connections"
 	worker "github.com/restuwahyu13/go-fast-search/internal/infrastructure/workers"
+	module "github.com/restuwahyu13/go-fast-search/internal/modules"
 	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
 	"github.com/restuwahyu13/go-fast-search/shared/dto"
 	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
 	opt "github.com/restuwahyu13/go-fast-search/shared/output"
 	"github.com/restuwahyu13/go-fast-search/shared/pkg"
 )
 
 type (
 	IWorker interface {
+		Middleware()
+		Module()
 		Listener()
 	}
 
 	Worker struct {
-		CTX  context.Context
-		ENV  dto.Request[dto.Environtment]
-		DB   *bun.DB
-		RDS  *redis.Client
-		AMQP *rabbitmq.Conn
-		MLS  meilisearch.ServiceManager
-	}
-
-	syncOnce struct {
-		searchWorker *sync.Once
-		dlqWorker    *sync.Once
+		CTX     context.Context
+		CANCEL  context.CancelFunc
+		ENV     dto.Request[dto.Environtment]
+		ENV_RES *opt.Environtment
+		ROUTER  *chi.Mux
+		DB      *bun.DB
+		RDS     *redis.Client
+		AMQP    *rabbitmq.Conn
+		MLS     meilisearch.ServiceManager
+		JOBS    inf.IConsumerRegistry
 	}
 )
 
@@ -70,7 +76,10 @@ func init() {
 }
 
 func main() {
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	router := chi.NewRouter()
 
 	db, err := con.SqlConnection(ctx, env)
 	if err != nil {
@@ -86,12 +95,22 @@ func main() {
 	}
 	defer rds.Close()
 
-	amqp, err := con.RabbitConnection(env)
+	amqp, err := con.BrokerConnection(env)
 	if err != nil {
 		pkg.Logrus(cons.FATAL, err)
 		return
 	}
-	defer amqp.Close()
+
+	if amqp != nil {
+		defer amqp.Close()
+
+		publisher, err := pkg.NewPublisher(amqp, env)
+		if err != nil {
+			pkg.Logrus(cons.FATAL, err)
+			return
+		}
+		defer publisher.Close()
+	}
 
 	mls := con.MeiliSearchConnection(env)
 	if !mls.IsHealthy() {
@@ -102,103 +121,149 @@ func main() {
 
 	req := dto.Request[Worker]{}
 	req.Option = Worker{
-		CTX:  ctx,
-		ENV:  env,
-		DB:   db,
-		RDS:  rds,
-		AMQP: amqp,
-		MLS:  mls,
+		CTX:     ctx,
+		CANCEL:  cancel,
+		ENV:     env,
+		ENV_RES: env_res,
+		ROUTER:  router,
+		DB:      db,
+		RDS:     rds,
+		AMQP:    amqp,
+		MLS:     mls,
+		JOBS:    worker.NewConsumerRegistry(ctx, env),
 	}
 
 	app := NewWorker(req)
+	app.Middleware()
+	app.Module()
 	app.Listener()
 }
 
 func NewWorker(req dto.Request[Worker]) IWorker {
 	return Worker{
-		CTX:  req.Option.CTX,
-		ENV:  req.Option.ENV,
-		DB:   req.Option.DB,
-		RDS:  req.Option.RDS,
-		AMQP: req.Option.AMQP,
-		MLS:  req.Option.MLS,
+		CTX:     req.Option.CTX,
+		CANCEL:  req.Option.CANCEL,
+		ENV:     req.Option.ENV,
+		ENV_RES: req.Option.ENV_RES,
+		ROUTER:  req.Option.ROUTER,
+		DB:      req.Option.DB,
+		RDS:     req.Option.RDS,
+		AMQP:    req.Option.AMQP,
+		MLS:     req.Option.MLS,
+		JOBS:    req.Option.JOBS,
 	}
 }
 
-func (w Worker) worker(wg *sync.WaitGroup, rso syncOnce) {
-	defer wg.Done()
-
-	rso.searchWorker.Do(func() {
-		worker.NewSearchWorker(dto.WorkerOptions{
-			CTX:  w.CTX,
-			ENV:  w.ENV,
-			DB:   w.DB,
-			RDS:  w.RDS,
-			AMQP: w.AMQP,
-			MLS:  w.MLS,
-		}).SearchRun()
-	})
+func (w Worker) Middleware() {
+	w.ROUTER.Use(middleware.Recoverer)
+	w.ROUTER.Use(mw.RealIP(&w.ENV.Config))
+	w.ROUTER.Use(middleware.NoCache)
+
+	w.ROUTER.MethodNotAllowed(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		helper.Api(rw, r, opt.Response{
+			StatCode: http.StatusMethodNotAllowed,
+			ErrMsg:   "Route method not allowed",
+		})
+	}))
+
+	w.ROUTER.NotFound(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		helper.Api(rw, r, opt.Response{
+			StatCode: http.StatusNotFound,
+			ErrMsg:   "Route not found",
+		})
+	}))
+}
 
-	rso.dlqWorker.Do(func() {
-		worker.NewDeadLetterQueueWorker(dto.WorkerOptions{
-			CTX:  w.CTX,
-			ENV:  w.ENV,
-			DB:   w.DB,
-			RDS:  w.RDS,
-			AMQP: w.AMQP,
-			MLS:  w.MLS,
-		}).DeadLetterQueueRun()
+func (w Worker) Module() {
+	module.NewJobsModule[inf.IJobsService](dto.RegistryModuleOptions[inf.IJobControl]{
+		ENV:      w.ENV,
+		RDS:      w.RDS,
+		REGISTRY: w.JOBS,
+		ROUTER:   w.ROUTER,
 	})
 }
 
-func (w Worker) register(wg *sync.WaitGroup) {
-	worker := runtime.NumCPU()
+/**
+* Internal server only for consumer control, keep the port private from public network
+ */
+func (w Worker) server() {
+	if w.ENV.Config.WORKER.PORT == "" {
+		pkg.Logrus(cons.INFO, "Worker internal server is disabled, WORKER_PORT is not set")
+		return
+	}
 
-	searchWorkerOnce := new(sync.Once)
-	dlqWorkerOnce := new(sync.Once)
+	err := pkg.Graceful(w.ENV, func() opt.Graceful {
+		return opt.Graceful{HANDLER: w.ROUTER, ENV: w.ENV_RES, PORT: w.ENV.Config.WORKER.PORT}
+	})
 
-	rso := syncOnce{
-		searchWorker: searchWorkerOnce,
-		dlqWorker:    dlqWorkerOnce,
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
 	}
+}
 
-	for i := 1; i <= worker; i++ {
-		wg.Add(1)
-		go w.worker(wg, rso)
-	}
+/**
+* Every worker only start its consumers, the consumer registry run the configured number of consumer
+* per queue and the deliveries are handled in background until the worker is shutting down
+ */
+func (w Worker) worker() {
+	worker.NewSearchWorker(dto.WorkerOptions{
+		CTX:  w.CTX,
+		ENV:  w.ENV,
+		DB:   w.DB,
+		RDS:  w.RDS,
+		AMQP: w.AMQP,
+		MLS:  w.MLS,
+	}, w.JOBS).SearchRun()
+
+	worker.NewDeadLetterQueueWorker(dto.WorkerOptions{
+		CTX:  w.CTX,
+		ENV:  w.ENV,
+		DB:   w.DB,
+		RDS:  w.RDS,
+		AMQP: w.AMQP,
+		MLS:  w.MLS,
+	}, w.JOBS).DeadLetterQueueRun()
+
+	worker.NewAuditWorker(dto.WorkerOptions{
+		CTX:  w.CTX,
+		ENV:  w.ENV,
+		DB:   w.DB,
+		RDS:  w.RDS,
+		AMQP: w.AMQP,
+		MLS:  w.MLS,
+	}, w.JOBS).AuditRun()
 }
 
+/**
+* Shutdown stop the consumers first and wait the deliveries in flight until the deadline, the context is
+* canceled afterward and the connections are closed by main once the listener return
+ */
 func (w Worker) Listener() {
-	wg := sync.WaitGroup{}
-	w.register(&wg)
+	w.worker()
 
-	ctx, cancel := context.WithCancel(w.CTX)
-	defer cancel()
+	go w.server()
 
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP, syscall.SIGQUIT, syscall.SIGALRM, syscall.SIGABRT, syscall.SIGUSR1)
 
-	for {
-		select {
-		case <-ch:
-			if w.ENV.Config.APP.ENV != cons.DEV {
-				time.Sleep(time.Second * 10)
-			} else {
-				time.Sleep(time.Second * 15)
-			}
-
-			wg.Wait()
-			os.Exit(0)
-			return
+	pkg.Logrus(cons.INFO, "Worker is running")
 
-		case <-ctx.Done():
-			wg.Wait()
-			return
+	select {
+	case sig := <-ch:
+		pkg.Logrus(cons.INFO, "Worker receive %s signal, shutting down", sig)
 
-		default:
-			wg.Wait()
-			time.Sleep(time.Second * 5)
-			pkg.Logrus(cons.INFO, "Worker is running")
-		}
+	case <-w.CTX.Done():
+	}
+
+	timeout := w.ENV.Config.WORKER.SHUTDOWN_TIMEOUT
+	if timeout < 1 {
+		timeout = cons.JOB_SHUTDOWN_DEFAULT_TIMEOUT
 	}
+
+	if err := w.JOBS.Shutdown(time.Duration(time.Second * time.Duration(timeout))); err != nil {
+		pkg.Logrus(cons.ERROR, err)
+	}
+
+	w.CANCEL()
+	pkg.Logrus(cons.INFO, "Worker is stopped")
 }
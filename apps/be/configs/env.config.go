@@ -1,11 +1,16 @@
 package config
 
 import (
+	"fmt"
+	"net/netip"
 	"os"
+	"strconv"
+	"strings"
 
 	genv "github.com/caarlos0/env"
 	"github.com/spf13/viper"
 
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
 	"github.com/restuwahyu13/go-fast-search/shared/dto"
 	opt "github.com/restuwahyu13/go-fast-search/shared/output"
 )
@@ -32,11 +37,38 @@ func NewEnvirontment(name, path, ext string) (*opt.Environtment, error) {
 		}
 	}
 
+	deleteMode, err := deleteMode(cfg.SCHEDULER_DELETE_MODE)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := oidc(cfg); err != nil {
+		return nil, err
+	}
+
+	trustedProxies, err := proxies(cfg.TRUSTED_PROXIES)
+	if err != nil {
+		return nil, err
+	}
+
+	dedupTTL, err := dedupTTL(cfg.WORKER_DEDUP_TTL, cfg.BROKER_SIGNATURE_TTL)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptionKeys := keyring(cfg.BROKER_ENCRYPTION_KEYS)
+
+	encryptionKeyID, err := encryptionKeyID(cfg.BROKER_ENCRYPTION_KEY_ID, encryptionKeys)
+	if err != nil {
+		return nil, err
+	}
+
 	return &opt.Environtment{
 		APP: opt.Application{
-			ENV:          cfg.ENV,
-			PORT:         cfg.PORT,
-			INBOUND_SIZE: cfg.INBOUND_SIZE,
+			ENV:             cfg.ENV,
+			PORT:            cfg.PORT,
+			INBOUND_SIZE:    cfg.INBOUND_SIZE,
+			TRUSTED_PROXIES: trustedProxies,
 		},
 		REDIS: opt.Redis{
 			URL: cfg.REDIS_CSN,
@@ -45,17 +77,285 @@ func NewEnvirontment(name, path, ext string) (*opt.Environtment, error) {
 			URL: cfg.PG_DSN,
 		},
 		JWT: opt.Jwt{
-			SECRET:  cfg.JWT_SECRET_KEY,
-			EXPIRED: cfg.JWT_EXPIRED,
+			SECRET:          cfg.JWT_SECRET_KEY,
+			EXPIRED:         cfg.JWT_EXPIRED,
+			REFRESH_EXPIRED: cfg.JWT_REFRESH_EXPIRED,
+			KEY_ROTATION:    cfg.JWT_KEY_ROTATION,
+			KEY_GRACE:       cfg.JWT_KEY_GRACE,
+		},
+		AUTH: opt.Auth{
+			PUBLIC_ROUTES: routes(cfg.AUTH_PUBLIC_ROUTES),
+			MODE:          cfg.AUTH_MODE,
+		},
+		OIDC: opt.Oidc{
+			JWKS_URL:     cfg.OIDC_JWKS_URL,
+			JWKS_REFRESH: cfg.OIDC_JWKS_REFRESH,
+			ISSUER:       cfg.OIDC_ISSUER,
+			AUDIENCE:     cfg.OIDC_AUDIENCE,
+			LEEWAY:       cfg.OIDC_LEEWAY,
+			ROLE_CLAIM:   cfg.OIDC_ROLE_CLAIM,
+			ROLES:        roles(cfg.OIDC_ROLES),
+			TENANT_CLAIM: cfg.OIDC_TENANT_CLAIM,
+		},
+		RATE_LIMIT: opt.RateLimit{
+			RULES: limits(cfg.RATE_LIMIT_RULES),
+		},
+		AUDIT: opt.Audit{
+			HASH_KEY: cfg.AUDIT_HASH_KEY,
+		},
+		BROKER: opt.Broker{
+			DRIVER:            cfg.BROKER_DRIVER,
+			SIGNING_KEY_ID:    cfg.BROKER_SIGNING_KEY_ID,
+			SIGNING_KEYS:      keyring(cfg.BROKER_SIGNING_KEYS),
+			SIGNATURE_TTL:     cfg.BROKER_SIGNATURE_TTL,
+			ENCRYPTION_KEY_ID: encryptionKeyID,
+			ENCRYPTION_KEYS:   encryptionKeys,
 		},
 		RABBITMQ: opt.RabbitMQ{
-			URL:    cfg.RABBITMQ_QSN,
-			VSN:    cfg.RABBITMQ_VSN,
-			SECRET: cfg.RABBITMQ_SECRET_KEY,
+			URL:             cfg.RABBITMQ_QSN,
+			VSN:             cfg.RABBITMQ_VSN,
+			SECRET:          cfg.RABBITMQ_SECRET_KEY,
+			RETRY_MAX:       cfg.RABBITMQ_RETRY_MAX,
+			RETRY_DELAY:     cfg.RABBITMQ_RETRY_DELAY,
+			POOL_SIZE:       cfg.RABBITMQ_POOL_SIZE,
+			CONFIRM_TTL:     cfg.RABBITMQ_CONFIRM_TTL,
+			PARTITIONS:      cfg.RABBITMQ_PARTITIONS,
+			TLS_SKIP_VERIFY: cfg.RABBITMQ_TLS_SKIP_VERIFY,
 		},
 		MEILISEARCH: opt.MeiliSearch{
 			URL: cfg.MEILI_DSN,
 			KEY: cfg.MEILI_MASTER_KEY,
 		},
+		SCHEDULER: opt.Scheduler{
+			LEASE_TTL:        cfg.SCHEDULER_LEASE_TTL,
+			DELETE_MODE:      deleteMode,
+			CRONTAB:          crontab(cfg.SCHEDULER_CRONTAB),
+			PORT:             cfg.SCHEDULER_PORT,
+			BATCH_SIZE:       cfg.SCHEDULER_BATCH_SIZE,
+			SHUTDOWN_TIMEOUT: cfg.SCHEDULER_SHUTDOWN_TIMEOUT,
+		},
+		WORKER: opt.Worker{
+			PORT:                  cfg.WORKER_PORT,
+			DEDUP_TTL:             dedupTTL,
+			VERSION_TTL:           cfg.WORKER_VERSION_TTL,
+			BATCH_SIZE:            cfg.WORKER_BATCH_SIZE,
+			BATCH_WAIT:            cfg.WORKER_BATCH_WAIT,
+			BACKPRESSURE_HIGH:     cfg.WORKER_BACKPRESSURE_HIGH,
+			BACKPRESSURE_LOW:      cfg.WORKER_BACKPRESSURE_LOW,
+			BACKPRESSURE_INTERVAL: cfg.WORKER_BACKPRESSURE_INTERVAL,
+			CONSUMERS:             cfg.WORKER_CONSUMERS,
+			SHUTDOWN_TIMEOUT:      cfg.WORKER_SHUTDOWN_TIMEOUT,
+		},
 	}, nil
 }
+
+// crontab parse SCHEDULER_CRONTAB with format job=expression separated by semicolon,
+// example: search=0 * * * * *;purge=0 0 * * * *
+func crontab(value string) map[string]string {
+	res := make(map[string]string)
+
+	for _, entry := range strings.Split(value, ";") {
+		name, expression, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+
+		res[strings.TrimSpace(name)] = strings.TrimSpace(expression)
+	}
+
+	return res
+}
+
+// keyring parse BROKER_SIGNING_KEYS and BROKER_ENCRYPTION_KEYS with format id=secret separated by semicolon,
+// old key is kept in the keyring until every message signed with it is consumed,
+// example: 2026-10=first-secret;2026-11=second-secret
+func keyring(value string) map[string]string {
+	res := make(map[string]string)
+
+	for _, entry := range strings.Split(value, ";") {
+		id, secret, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(id) == "" {
+			continue
+		}
+
+		res[strings.TrimSpace(id)] = strings.TrimSpace(secret)
+	}
+
+	return res
+}
+
+// routes parse AUTH_PUBLIC_ROUTES with format method path separated by semicolon, path accept wildcard pattern
+// where * match one segment and trailing /** match every path below, example: GET /api/v1/users;POST /api/v1/users/*
+func routes(value string) []string {
+	res := []string{}
+
+	for _, entry := range strings.Split(value, ";") {
+		method, path, ok := strings.Cut(strings.TrimSpace(entry), " ")
+		if !ok {
+			continue
+		}
+
+		res = append(res, fmt.Sprintf("%s %s", strings.ToUpper(method), strings.TrimSpace(path)))
+	}
+
+	return res
+}
+
+// roles parse OIDC_ROLES with format claim value=role separated by semicolon, claim value without mapping grant no role,
+// example: search-admins=admin;helpdesk=support
+func roles(value string) map[string]string {
+	res := make(map[string]string)
+
+	for _, entry := range strings.Split(value, ";") {
+		claim, role, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(claim) == "" {
+			continue
+		}
+
+		res[strings.TrimSpace(claim)] = strings.TrimSpace(role)
+	}
+
+	return res
+}
+
+// limits parse RATE_LIMIT_RULES with format method path@identity=limit/seconds separated by semicolon, identity is
+// optional and one of key, user or ip, the first matching rule is applied, path is matched like AUTH_PUBLIC_ROUTES,
+// example: GET /api/v1/users@ip=30/60;GET /api/v1/users=300/60;* /api/v1/**=600/60
+func limits(value string) []opt.RateLimitRule {
+	res := []opt.RateLimitRule{}
+
+	for _, entry := range strings.Split(value, ";") {
+		rule, quota, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			continue
+		}
+
+		limit, window, ok := strings.Cut(quota, "/")
+		if !ok {
+			continue
+		}
+
+		limitValue, err := strconv.Atoi(strings.TrimSpace(limit))
+		if err != nil || limitValue < 1 {
+			continue
+		}
+
+		windowValue, err := strconv.Atoi(strings.TrimSpace(window))
+		if err != nil || windowValue < 1 {
+			continue
+		}
+
+		route, identity, _ := strings.Cut(rule, "@")
+
+		method, path, ok := strings.Cut(strings.TrimSpace(route), " ")
+		if !ok {
+			continue
+		}
+
+		res = append(res, opt.RateLimitRule{
+			ROUTE:    fmt.Sprintf("%s %s", strings.ToUpper(method), strings.TrimSpace(path)),
+			IDENTITY: strings.TrimSpace(identity),
+			LIMIT:    limitValue,
+			WINDOW:   windowValue,
+		})
+	}
+
+	return res
+}
+
+// deleteMode parse SCHEDULER_DELETE_MODE, empty value default to tombstone and unknown value is rejected
+func deleteMode(value string) (string, error) {
+	switch strings.TrimSpace(value) {
+
+	case "", cons.DELETE_MODE_TOMBSTONE:
+		return cons.DELETE_MODE_TOMBSTONE, nil
+
+	case cons.DELETE_MODE_HARD:
+		return cons.DELETE_MODE_HARD, nil
+
+	default:
+		return "", fmt.Errorf("SCHEDULER_DELETE_MODE %s is not supported, use %s or %s", value, cons.DELETE_MODE_TOMBSTONE, cons.DELETE_MODE_HARD)
+	}
+}
+
+// encryptionKeyID default BROKER_ENCRYPTION_KEY_ID to the default key id like the signing key,
+// the key id must exist in BROKER_ENCRYPTION_KEYS otherwise every published message is rejected
+func encryptionKeyID(value string, keys map[string]string) (string, error) {
+	if len(keys) < 1 {
+		return value, nil
+	}
+
+	keyID := strings.TrimSpace(value)
+	if keyID == "" {
+		keyID = cons.SIGNATURE_DEFAULT_KEY_ID
+	}
+
+	if _, ok := keys[keyID]; !ok {
+		return "", fmt.Errorf("BROKER_ENCRYPTION_KEY_ID %s is not found in BROKER_ENCRYPTION_KEYS", keyID)
+	}
+
+	return keyID, nil
+}
+
+// dedupTTL default WORKER_DEDUP_TTL to BROKER_SIGNATURE_TTL and reject a shorter one, a replayed message
+// still carrying a valid signature after its dedup key expired would be applied twice
+func dedupTTL(value, signatureTTL int) (int, error) {
+	if signatureTTL <= 0 {
+		signatureTTL = cons.SIGNATURE_DEFAULT_TTL
+	}
+
+	if value <= 0 {
+		return signatureTTL, nil
+	}
+
+	if value < signatureTTL {
+		return 0, fmt.Errorf("WORKER_DEDUP_TTL %d is shorter than BROKER_SIGNATURE_TTL %d", value, signatureTTL)
+	}
+
+	return value, nil
+}
+
+// oidc require OIDC_JWKS_URL, OIDC_ISSUER and OIDC_AUDIENCE when AUTH_MODE accept oidc token,
+// without audience any token issued by the identity provider for another client would be accepted
+func oidc(cfg dto.Config) error {
+	if cfg.AUTH_MODE != cons.AUTH_MODE_OIDC && cfg.AUTH_MODE != cons.AUTH_MODE_BOTH {
+		return nil
+	}
+
+	required := [][2]string{{"OIDC_JWKS_URL", cfg.OIDC_JWKS_URL}, {"OIDC_ISSUER", cfg.OIDC_ISSUER}, {"OIDC_AUDIENCE", cfg.OIDC_AUDIENCE}}
+
+	for _, field := range required {
+		if strings.TrimSpace(field[1]) == "" {
+			return fmt.Errorf("%s is required when AUTH_MODE is %s", field[0], cfg.AUTH_MODE)
+		}
+	}
+
+	return nil
+}
+
+// proxies parse TRUSTED_PROXIES with format ip or cidr separated by semicolon, forwarded client ip header is
+// only read from these proxies, example: 10.0.0.0/8;172.16.0.1
+func proxies(value string) ([]netip.Prefix, error) {
+	res := []netip.Prefix{}
+
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			res = append(res, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return nil, fmt.Errorf("TRUSTED_PROXIES %s is not valid ip or cidr", entry)
+		}
+
+		res = append(res, prefix.Masked())
+	}
+
+	return res, nil
+}
@@ -63,11 +63,14 @@ func NewTest() Test {
 	}
 	defer rds.Close()
 
-	amqp, err := con.RabbitConnection(env)
+	amqp, err := con.BrokerConnection(env)
 	if err != nil {
 		pkg.Logrus(cons.FATAL, err)
 	}
-	defer amqp.Close()
+
+	if amqp != nil {
+		defer amqp.Close()
+	}
 
 	mls := con.MeiliSearchConnection(env)
 	if !mls.IsHealthy() {
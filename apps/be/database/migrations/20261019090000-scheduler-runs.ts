@@ -0,0 +1,33 @@
+import { QueryInterface, Sequelize, DataTypes } from 'sequelize'
+
+module.exports = {
+	up: async (queryInterface: QueryInterface, sequelize: Sequelize) => {
+		const tablExist: boolean = await queryInterface.tableExists('scheduler_runs')
+		if (!tablExist) {
+			await queryInterface.createTable(
+				'scheduler_runs',
+				{
+					id: { type: DataTypes.UUID, primaryKey: true, allowNull: false, unique: true, defaultValue: sequelize.literal('uuid_generate_v4()') },
+					job_name: { type: DataTypes.STRING(200), allowNull: false },
+					holder: { type: DataTypes.STRING(200) },
+					status: { type: DataTypes.STRING(50), allowNull: false },
+					error: { type: DataTypes.TEXT },
+					started_at: { type: DataTypes.DATE, allowNull: false, defaultValue: sequelize.literal('CURRENT_TIMESTAMP') },
+					finished_at: { type: DataTypes.DATE },
+					created_at: { type: DataTypes.DATE, allowNull: false, defaultValue: sequelize.literal('CURRENT_TIMESTAMP') }
+				},
+				{
+					logging: true
+				}
+			)
+
+			await queryInterface.addIndex('scheduler_runs', ['job_name', 'started_at'], { logging: true })
+		}
+	},
+	down: async (queryInterface: QueryInterface, _sequelize: Sequelize) => {
+		const tableExist: boolean = await queryInterface.tableExists('scheduler_runs')
+		if (tableExist) {
+			return queryInterface.dropTable('scheduler_runs')
+		}
+	}
+}
@@ -0,0 +1,38 @@
+import { QueryInterface, Sequelize, DataTypes } from 'sequelize'
+
+module.exports = {
+	up: async (queryInterface: QueryInterface, sequelize: Sequelize) => {
+		const tablExist: boolean = await queryInterface.tableExists('dead_letters')
+		if (!tablExist) {
+			await queryInterface.createTable(
+				'dead_letters',
+				{
+					id: { type: DataTypes.UUID, primaryKey: true, allowNull: false, unique: true, defaultValue: sequelize.literal('uuid_generate_v4()') },
+					exchange: { type: DataTypes.STRING(200), allowNull: false },
+					queue: { type: DataTypes.STRING(200), allowNull: false },
+					document_id: { type: DataTypes.STRING(200) },
+					action: { type: DataTypes.STRING(50) },
+					payload: { type: DataTypes.JSONB, allowNull: false },
+					error: { type: DataTypes.TEXT },
+					attempt: { type: DataTypes.INTEGER, allowNull: false, defaultValue: 0 },
+					status: { type: DataTypes.STRING(50), allowNull: false },
+					created_at: { type: DataTypes.DATE, allowNull: false, defaultValue: sequelize.literal('CURRENT_TIMESTAMP') },
+					updated_at: { type: DataTypes.DATE },
+					replayed_at: { type: DataTypes.DATE }
+				},
+				{
+					logging: true
+				}
+			)
+
+			await queryInterface.addIndex('dead_letters', ['document_id'], { logging: true })
+			await queryInterface.addIndex('dead_letters', ['status', 'created_at'], { logging: true })
+		}
+	},
+	down: async (queryInterface: QueryInterface, _sequelize: Sequelize) => {
+		const tableExist: boolean = await queryInterface.tableExists('dead_letters')
+		if (tableExist) {
+			return queryInterface.dropTable('dead_letters')
+		}
+	}
+}
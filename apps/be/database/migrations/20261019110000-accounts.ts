@@ -0,0 +1,31 @@
+import { QueryInterface, Sequelize, DataTypes } from 'sequelize'
+
+module.exports = {
+	up: async (queryInterface: QueryInterface, sequelize: Sequelize) => {
+		const tablExist: boolean = await queryInterface.tableExists('accounts')
+		if (!tablExist) {
+			await queryInterface.createTable(
+				'accounts',
+				{
+					id: { type: DataTypes.UUID, primaryKey: true, allowNull: false, unique: true, defaultValue: sequelize.literal('uuid_generate_v4()') },
+					username: { type: DataTypes.STRING(200), allowNull: false, unique: true },
+					password: { type: DataTypes.TEXT, allowNull: false },
+					is_active: { type: DataTypes.BOOLEAN, allowNull: false, defaultValue: true },
+					last_login_at: { type: DataTypes.DATE },
+					created_at: { type: DataTypes.DATE, allowNull: false, defaultValue: sequelize.literal('CURRENT_TIMESTAMP') },
+					updated_at: { type: DataTypes.DATE },
+					deleted_at: { type: DataTypes.DATE }
+				},
+				{
+					logging: true
+				}
+			)
+		}
+	},
+	down: async (queryInterface: QueryInterface, _sequelize: Sequelize) => {
+		const tableExist: boolean = await queryInterface.tableExists('accounts')
+		if (tableExist) {
+			return queryInterface.dropTable('accounts')
+		}
+	}
+}
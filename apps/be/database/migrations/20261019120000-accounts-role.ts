@@ -0,0 +1,16 @@
+import { QueryInterface, Sequelize, DataTypes } from 'sequelize'
+
+module.exports = {
+	up: async (queryInterface: QueryInterface, _sequelize: Sequelize) => {
+		const table: Record<string, any> = await queryInterface.describeTable('accounts')
+		if (!table.role) {
+			await queryInterface.addColumn('accounts', 'role', { type: DataTypes.STRING(50), allowNull: false, defaultValue: 'support' })
+		}
+	},
+	down: async (queryInterface: QueryInterface, _sequelize: Sequelize) => {
+		const table: Record<string, any> = await queryInterface.describeTable('accounts')
+		if (table.role) {
+			return queryInterface.removeColumn('accounts', 'role')
+		}
+	}
+}
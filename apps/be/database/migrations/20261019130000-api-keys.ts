@@ -0,0 +1,38 @@
+import { QueryInterface, Sequelize, DataTypes } from 'sequelize'
+
+module.exports = {
+	up: async (queryInterface: QueryInterface, sequelize: Sequelize) => {
+		const tablExist: boolean = await queryInterface.tableExists('api_keys')
+		if (!tablExist) {
+			await queryInterface.createTable(
+				'api_keys',
+				{
+					id: { type: DataTypes.UUID, primaryKey: true, allowNull: false, unique: true, defaultValue: sequelize.literal('uuid_generate_v4()') },
+					name: { type: DataTypes.STRING(200), allowNull: false },
+					owner: { type: DataTypes.STRING(200), allowNull: false },
+					prefix: { type: DataTypes.STRING(50), allowNull: false, unique: true },
+					hash: { type: DataTypes.STRING(64), allowNull: false },
+					scopes: { type: DataTypes.ARRAY(DataTypes.STRING(50)), allowNull: false },
+					created_by: { type: DataTypes.UUID },
+					expired_at: { type: DataTypes.DATE },
+					last_used_at: { type: DataTypes.DATE },
+					rotated_at: { type: DataTypes.DATE },
+					revoked_at: { type: DataTypes.DATE },
+					created_at: { type: DataTypes.DATE, allowNull: false, defaultValue: sequelize.literal('CURRENT_TIMESTAMP') },
+					updated_at: { type: DataTypes.DATE }
+				},
+				{
+					logging: true
+				}
+			)
+
+			await queryInterface.addIndex('api_keys', ['owner'], { logging: true })
+		}
+	},
+	down: async (queryInterface: QueryInterface, _sequelize: Sequelize) => {
+		const tableExist: boolean = await queryInterface.tableExists('api_keys')
+		if (tableExist) {
+			return queryInterface.dropTable('api_keys')
+		}
+	}
+}
@@ -0,0 +1,62 @@
+import { QueryInterface, Sequelize, DataTypes } from 'sequelize'
+
+module.exports = {
+	up: async (queryInterface: QueryInterface, sequelize: Sequelize) => {
+		const tablExist: boolean = await queryInterface.tableExists('audit_logs')
+		if (!tablExist) {
+			await queryInterface.createTable(
+				'audit_logs',
+				{
+					id: { type: DataTypes.UUID, primaryKey: true, allowNull: false, unique: true, defaultValue: sequelize.literal('uuid_generate_v4()') },
+					actor: { type: DataTypes.STRING(200), allowNull: false },
+					actor_type: { type: DataTypes.STRING(50), allowNull: false },
+					action: { type: DataTypes.STRING(50), allowNull: false },
+					target_type: { type: DataTypes.STRING(50), allowNull: false },
+					target_id: { type: DataTypes.STRING(200) },
+					changes: { type: DataTypes.JSONB },
+					metadata: { type: DataTypes.JSONB },
+					request_id: { type: DataTypes.STRING(200) },
+					ip_address: { type: DataTypes.STRING(100) },
+					occurred_at: { type: DataTypes.DATE, allowNull: false },
+					created_at: { type: DataTypes.DATE, allowNull: false, defaultValue: sequelize.literal('CURRENT_TIMESTAMP') }
+				},
+				{
+					logging: true
+				}
+			)
+
+			await queryInterface.addIndex('audit_logs', ['actor', 'occurred_at'], { logging: true })
+			await queryInterface.addIndex('audit_logs', ['target_id', 'occurred_at'], { logging: true })
+			await queryInterface.addIndex('audit_logs', ['occurred_at'], { logging: true })
+
+			/**
+			 * Audit log is append only, update, delete and truncate are rejected for every role including the owner
+			 */
+			await queryInterface.sequelize.query(
+				`CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+				BEGIN
+					RAISE EXCEPTION 'audit_logs is append only, % is not allowed', TG_OP;
+				END;
+				$$ LANGUAGE plpgsql`,
+				{ logging: true }
+			)
+
+			await queryInterface.sequelize.query(
+				`CREATE TRIGGER audit_logs_append_only BEFORE UPDATE OR DELETE ON audit_logs FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only()`,
+				{ logging: true }
+			)
+
+			await queryInterface.sequelize.query(
+				`CREATE TRIGGER audit_logs_append_only_truncate BEFORE TRUNCATE ON audit_logs FOR EACH STATEMENT EXECUTE FUNCTION audit_logs_append_only()`,
+				{ logging: true }
+			)
+		}
+	},
+	down: async (queryInterface: QueryInterface, _sequelize: Sequelize) => {
+		const tableExist: boolean = await queryInterface.tableExists('audit_logs')
+		if (tableExist) {
+			await queryInterface.dropTable('audit_logs')
+			await queryInterface.sequelize.query('DROP FUNCTION IF EXISTS audit_logs_append_only()', { logging: true })
+		}
+	}
+}
@@ -0,0 +1,16 @@
+import { QueryInterface, Sequelize, DataTypes } from 'sequelize'
+
+module.exports = {
+	up: async (queryInterface: QueryInterface, _sequelize: Sequelize) => {
+		const table: Record<string, any> = await queryInterface.describeTable('users')
+		if (!table.sync_token) {
+			await queryInterface.addColumn('users', 'sync_token', { type: DataTypes.BIGINT, allowNull: false, defaultValue: 0 })
+		}
+	},
+	down: async (queryInterface: QueryInterface, _sequelize: Sequelize) => {
+		const table: Record<string, any> = await queryInterface.describeTable('users')
+		if (table.sync_token) {
+			return queryInterface.removeColumn('users', 'sync_token')
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package entitie
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6/zero"
+	"github.com/uptrace/bun"
+)
+
+type AccountsEntitie struct {
+	bun.BaseModel `bun:"table:accounts,alias:accounts"`
+	ID            string    `json:"id" bun:"id,pk,default:uuid_generate_v4()"`
+	Username      string    `json:"username" bun:"username,unique,notnull"`
+	Password      string    `json:"-" bun:"password,notnull"`
+	Role          string    `json:"role" bun:"role,notnull,default:'support'"`
+	IsActive      bool      `json:"is_active" bun:"is_active,notnull,default:true"`
+	LastLoginAt   zero.Time `json:"last_login_at" bun:"last_login_at,nullzero"`
+	CreatedAt     time.Time `json:"created_at" bun:"created_at,default:current_timestamp"`
+	UpdatedAt     zero.Time `json:"updated_at" bun:"updated_at,nullzero"`
+	DeletedAt     zero.Time `json:"deleted_at" bun:"deleted_at,nullzero"`
+}
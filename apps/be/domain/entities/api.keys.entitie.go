@@ -0,0 +1,25 @@
+package entitie
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6/zero"
+	"github.com/uptrace/bun"
+)
+
+type ApiKeysEntitie struct {
+	bun.BaseModel `bun:"table:api_keys,alias:api_keys"`
+	ID            string    `json:"id" bun:"id,pk,default:uuid_generate_v4()"`
+	Name          string    `json:"name" bun:"name,notnull"`
+	Owner         string    `json:"owner" bun:"owner,notnull"`
+	Prefix        string    `json:"prefix" bun:"prefix,unique,notnull"`
+	Hash          string    `json:"-" bun:"hash,notnull"`
+	Scopes        []string  `json:"scopes" bun:"scopes,array,notnull"`
+	CreatedBy     string    `json:"created_by,omitempty" bun:"created_by,nullzero"`
+	ExpiredAt     zero.Time `json:"expired_at" bun:"expired_at,nullzero"`
+	LastUsedAt    zero.Time `json:"last_used_at" bun:"last_used_at,nullzero"`
+	RotatedAt     zero.Time `json:"rotated_at" bun:"rotated_at,nullzero"`
+	RevokedAt     zero.Time `json:"revoked_at" bun:"revoked_at,nullzero"`
+	CreatedAt     time.Time `json:"created_at" bun:"created_at,default:current_timestamp"`
+	UpdatedAt     zero.Time `json:"updated_at" bun:"updated_at,nullzero"`
+}
@@ -0,0 +1,23 @@
+package entitie
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type AuditLogsEntitie struct {
+	bun.BaseModel `bun:"table:audit_logs,alias:audit_logs"`
+	ID            string         `json:"id" bun:"id,pk,default:uuid_generate_v4()"`
+	Actor         string         `json:"actor" bun:"actor,notnull"`
+	ActorType     string         `json:"actor_type" bun:"actor_type,notnull"`
+	Action        string         `json:"action" bun:"action,notnull"`
+	TargetType    string         `json:"target_type" bun:"target_type,notnull"`
+	TargetID      string         `json:"target_id" bun:"target_id,nullzero"`
+	Changes       map[string]any `json:"changes" bun:"changes,type:jsonb,nullzero"`
+	Metadata      map[string]any `json:"metadata" bun:"metadata,type:jsonb,nullzero"`
+	RequestID     string         `json:"request_id" bun:"request_id,nullzero"`
+	IPAddress     string         `json:"ip_address" bun:"ip_address,nullzero"`
+	OccurredAt    time.Time      `json:"occurred_at" bun:"occurred_at,notnull"`
+	CreatedAt     time.Time      `json:"created_at" bun:"created_at,default:current_timestamp"`
+}
@@ -0,0 +1,24 @@
+package entitie
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6/zero"
+	"github.com/uptrace/bun"
+)
+
+type DeadLettersEntitie struct {
+	bun.BaseModel `bun:"table:dead_letters,alias:dead_letters"`
+	ID            string         `json:"id" bun:"id,pk,default:uuid_generate_v4()"`
+	Exchange      string         `json:"exchange" bun:"exchange,notnull"`
+	Queue         string         `json:"queue" bun:"queue,notnull"`
+	DocumentID    string         `json:"document_id" bun:"document_id,nullzero"`
+	Action        string         `json:"action" bun:"action,nullzero"`
+	Payload       map[string]any `json:"payload" bun:"payload,type:jsonb,notnull"`
+	Error         string         `json:"error,omitempty" bun:"error,nullzero"`
+	Attempt       int            `json:"attempt" bun:"attempt,notnull"`
+	Status        string         `json:"status" bun:"status,notnull"`
+	CreatedAt     time.Time      `json:"created_at" bun:"created_at,default:current_timestamp"`
+	UpdatedAt     zero.Time      `json:"updated_at" bun:"updated_at,nullzero"`
+	ReplayedAt    zero.Time      `json:"replayed_at" bun:"replayed_at,nullzero"`
+}
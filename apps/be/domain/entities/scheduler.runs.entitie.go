@@ -0,0 +1,20 @@
+package entitie
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6/zero"
+	"github.com/uptrace/bun"
+)
+
+type SchedulerRunsEntitie struct {
+	bun.BaseModel `bun:"table:scheduler_runs,alias:scheduler_runs"`
+	ID            string    `json:"id" bun:"id,pk,default:uuid_generate_v4()"`
+	JobName       string    `json:"job_name" bun:"job_name,notnull"`
+	Holder        string    `json:"holder" bun:"holder,nullzero"`
+	Status        string    `json:"status" bun:"status,notnull"`
+	Error         string    `json:"error,omitempty" bun:"error,nullzero"`
+	StartedAt     time.Time `json:"started_at" bun:"started_at,default:current_timestamp"`
+	FinishedAt    zero.Time `json:"finished_at" bun:"finished_at,nullzero"`
+	CreatedAt     time.Time `json:"created_at" bun:"created_at,default:current_timestamp"`
+}
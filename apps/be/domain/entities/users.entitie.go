@@ -22,6 +22,7 @@ type UsersEntitie struct {
 	Country       string    `json:"country" bun:"country,notnull"`
 	PostalCode    string    `json:"postal_code" bun:"postal_code,notnull"`
 	IsSync        bool      `json:"is_sync" bun:"is_sync,notnull,default:false" `
+	SyncToken     int64     `json:"-" bun:"sync_token,notnull,default:0"`
 	CreatedAt     time.Time `json:"created_at" bun:"created_at,default:current_timestamp"`
 	UpdatedAt     zero.Time `json:"updated_at" bun:"updated_at,nullzero"`
 	DeletedAt     zero.Time `json:"deleted_at" bun:"deleted_at,nullzero"`
@@ -0,0 +1,37 @@
+package exception
+
+import (
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type apiKeysException struct{}
+
+func NewApiKeysException() inf.IApiKeysException {
+	return apiKeysException{}
+}
+
+func (e apiKeysException) CreateApiKey(key string) string {
+	msg := make(map[string]string)
+
+	msg["expired_at_passed"] = "Expired at must be in the future"
+	msg["create_api_key_failed"] = "Create new api key failed"
+
+	return msg[key]
+}
+
+func (e apiKeysException) ApiKey(key string) string {
+	msg := make(map[string]string)
+
+	msg["api_key_notfound"] = "Api key is not exists in our system"
+	msg["api_key_revoked"] = "Api key is already revoked"
+
+	return msg[key]
+}
+
+func (e apiKeysException) AuthenticateApiKey(key string) string {
+	msg := make(map[string]string)
+
+	msg["invalid_api_key"] = "Invalid api key"
+
+	return msg[key]
+}
@@ -0,0 +1,19 @@
+package exception
+
+import (
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type auditLogsException struct{}
+
+func NewAuditLogsException() inf.IAuditLogsException {
+	return auditLogsException{}
+}
+
+func (e auditLogsException) FindAllAuditLogs(key string) string {
+	msg := make(map[string]string)
+
+	msg["audit_logs_range_invalid"] = "Audit log time range from must be before to"
+
+	return msg[key]
+}
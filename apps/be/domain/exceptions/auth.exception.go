@@ -0,0 +1,53 @@
+package exception
+
+import (
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type authException struct{}
+
+func NewAuthException() inf.IAuthException {
+	return authException{}
+}
+
+func (e authException) Login(key string) string {
+	msg := make(map[string]string)
+
+	msg["invalid_credentials"] = "Username or password is invalid"
+
+	return msg[key]
+}
+
+func (e authException) Refresh(key string) string {
+	msg := make(map[string]string)
+
+	msg["invalid_refresh_token"] = "Refresh token is invalid or expired"
+
+	return msg[key]
+}
+
+func (e authException) RevokeSessions(key string) string {
+	msg := make(map[string]string)
+
+	msg["account_notfound"] = "Account is not exists in our system"
+
+	return msg[key]
+}
+
+func (e authException) CreateAccount(key string) string {
+	msg := make(map[string]string)
+
+	msg["account_exists"] = "Account already exists in our system"
+	msg["create_account_failed"] = "Create new account failed"
+
+	return msg[key]
+}
+
+func (e authException) UpdateAccountRole(key string) string {
+	msg := make(map[string]string)
+
+	msg["account_notfound"] = "Account is not exists in our system"
+	msg["update_account_role_failed"] = "Update account role failed"
+
+	return msg[key]
+}
@@ -0,0 +1,37 @@
+package exception
+
+import (
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type deadLettersException struct{}
+
+func NewDeadLettersException() inf.IDeadLettersException {
+	return deadLettersException{}
+}
+
+func (e deadLettersException) FindDeadLetter(key string) string {
+	msg := make(map[string]string)
+
+	msg["dead_letter_notfound"] = "Dead letter is not exists in our system"
+
+	return msg[key]
+}
+
+func (e deadLettersException) ReplayDeadLetter(key string) string {
+	msg := make(map[string]string)
+
+	msg["dead_letter_notfound"] = "Dead letter is not exists in our system"
+	msg["dead_letter_not_parked"] = "Only parked dead letter can be replayed"
+
+	return msg[key]
+}
+
+func (e deadLettersException) DiscardDeadLetter(key string) string {
+	msg := make(map[string]string)
+
+	msg["dead_letter_notfound"] = "Dead letter is not exists in our system"
+	msg["dead_letter_closed"] = "Dead letter is already replayed or discarded"
+
+	return msg[key]
+}
@@ -0,0 +1,22 @@
+package exception
+
+import (
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type jobsException struct{}
+
+func NewJobsException() inf.IJobsException {
+	return jobsException{}
+}
+
+func (e jobsException) JobControl(key string) string {
+	msg := make(map[string]string)
+
+	msg["job_notfound"] = "Job is not registered in this process"
+	msg["job_standby"] = "Job lease is held by another instance, try the leader instance"
+	msg["job_running"] = "Job previous run is still in progress"
+	msg["job_not_triggerable"] = "Job cannot be triggered manually"
+
+	return msg[key]
+}
@@ -0,0 +1,19 @@
+package exception
+
+import (
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type leasesException struct{}
+
+func NewLeasesException() inf.ILeasesException {
+	return leasesException{}
+}
+
+func (e leasesException) FindLease(key string) string {
+	msg := make(map[string]string)
+
+	msg["lease_notfound"] = "Lease is not exists in our system"
+
+	return msg[key]
+}
@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type accountsRepositorie struct {
+	ctx     context.Context
+	db      *bun.DB
+	entitie *entitie.AccountsEntitie
+}
+
+func NewAccountsRepositorie(ctx context.Context, db *bun.DB) inf.IAccountsRepositorie {
+	return accountsRepositorie{ctx: ctx, db: db, entitie: new(entitie.AccountsEntitie)}
+}
+
+func (r accountsRepositorie) Find() *bun.SelectQuery {
+	return r.db.NewSelect().Model(r.entitie)
+}
+
+func (r accountsRepositorie) FindOne() *bun.SelectQuery {
+	return r.db.NewSelect().Model(r.entitie)
+}
+
+func (r accountsRepositorie) Insert(entitie entitie.AccountsEntitie, column string, dest ...any) error {
+	sqlb := r.db.NewInsert().Model(&entitie)
+
+	if column != "" && dest != nil {
+		sqlb = sqlb.Returning(column)
+	}
+
+	result, err := sqlb.Exec(r.ctx, dest...)
+	if err != nil {
+		return err
+
+	} else if rows, err := result.RowsAffected(); err != nil || rows < 1 {
+		if err != nil {
+			return err
+		}
+
+		return cons.NO_ROWS_AFFECTED
+
+	}
+
+	return nil
+}
+
+func (r accountsRepositorie) Update(entitie entitie.AccountsEntitie) error {
+	result, err := r.db.NewUpdate().Model(&entitie).Where("deleted_at IS NULL AND id = ?", entitie.ID).OmitZero().Exec(r.ctx)
+	if err != nil {
+		return err
+
+	} else if rows, err := result.RowsAffected(); err != nil || rows < 1 {
+		if err != nil {
+			return err
+		}
+
+		return cons.NO_ROWS_AFFECTED
+
+	}
+
+	return nil
+}
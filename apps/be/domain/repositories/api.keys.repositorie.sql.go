@@ -0,0 +1,82 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type apiKeysRepositorie struct {
+	ctx     context.Context
+	db      *bun.DB
+	entitie *entitie.ApiKeysEntitie
+}
+
+func NewApiKeysRepositorie(ctx context.Context, db *bun.DB) inf.IApiKeysRepositorie {
+	return apiKeysRepositorie{ctx: ctx, db: db, entitie: new(entitie.ApiKeysEntitie)}
+}
+
+func (r apiKeysRepositorie) Find() *bun.SelectQuery {
+	return r.db.NewSelect().Model(r.entitie)
+}
+
+func (r apiKeysRepositorie) FindOne() *bun.SelectQuery {
+	return r.db.NewSelect().Model(r.entitie)
+}
+
+func (r apiKeysRepositorie) Insert(entitie entitie.ApiKeysEntitie, column string, dest ...any) error {
+	sqlb := r.db.NewInsert().Model(&entitie)
+
+	if column != "" && dest != nil {
+		sqlb = sqlb.Returning(column)
+	}
+
+	result, err := sqlb.Exec(r.ctx, dest...)
+	if err != nil {
+		return err
+
+	} else if rows, err := result.RowsAffected(); err != nil || rows < 1 {
+		if err != nil {
+			return err
+		}
+
+		return cons.NO_ROWS_AFFECTED
+
+	}
+
+	return nil
+}
+
+// Update only update active api key, revoked api key is immutable
+func (r apiKeysRepositorie) Update(entitie entitie.ApiKeysEntitie) error {
+	result, err := r.db.NewUpdate().Model(&entitie).Where("revoked_at IS NULL AND id = ?", entitie.ID).OmitZero().Exec(r.ctx)
+	if err != nil {
+		return err
+
+	} else if rows, err := result.RowsAffected(); err != nil || rows < 1 {
+		if err != nil {
+			return err
+		}
+
+		return cons.NO_ROWS_AFFECTED
+
+	}
+
+	return nil
+}
+
+// Touch update last used at, at most once per interval to avoid a write on every request
+func (r apiKeysRepositorie) Touch(id string, interval time.Duration) error {
+	_, err := r.db.NewUpdate().Model(r.entitie).
+		Set("last_used_at = current_timestamp").
+		Where("id = ?", id).
+		Where("last_used_at IS NULL OR last_used_at < ?", time.Now().Add(-interval)).
+		Exec(r.ctx)
+
+	return err
+}
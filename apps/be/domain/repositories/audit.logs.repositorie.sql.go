@@ -0,0 +1,40 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type auditLogsRepositorie struct {
+	ctx     context.Context
+	db      *bun.DB
+	entitie *entitie.AuditLogsEntitie
+}
+
+/**
+* Audit log is append only, the table reject update and delete so the repositorie only expose find and insert
+ */
+func NewAuditLogsRepositorie(ctx context.Context, db *bun.DB) inf.IAuditLogsRepositorie {
+	return auditLogsRepositorie{ctx: ctx, db: db, entitie: new(entitie.AuditLogsEntitie)}
+}
+
+func (r auditLogsRepositorie) Find() *bun.SelectQuery {
+	return r.db.NewSelect().Model(r.entitie)
+}
+
+// Insert ignore entry already written, id is the event id so redelivered event is not written twice
+func (r auditLogsRepositorie) Insert(entities ...entitie.AuditLogsEntitie) error {
+	if len(entities) < 1 {
+		return nil
+	}
+
+	if _, err := r.db.NewInsert().Model(&entities).On("CONFLICT (id) DO NOTHING").Exec(r.ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
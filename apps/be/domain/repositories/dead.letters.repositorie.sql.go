@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type deadLettersRepositorie struct {
+	ctx     context.Context
+	db      *bun.DB
+	entitie *entitie.DeadLettersEntitie
+}
+
+func NewDeadLettersRepositorie(ctx context.Context, db *bun.DB) inf.IDeadLettersRepositorie {
+	return deadLettersRepositorie{ctx: ctx, db: db, entitie: new(entitie.DeadLettersEntitie)}
+}
+
+func (r deadLettersRepositorie) Find() *bun.SelectQuery {
+	return r.db.NewSelect().Model(r.entitie)
+}
+
+func (r deadLettersRepositorie) FindOne() *bun.SelectQuery {
+	return r.db.NewSelect().Model(r.entitie)
+}
+
+func (r deadLettersRepositorie) Upsert(entitie entitie.DeadLettersEntitie) error {
+	result, err := r.db.NewInsert().Model(&entitie).
+		On("CONFLICT (id) DO UPDATE").
+		Set("attempt = EXCLUDED.attempt").
+		Set("error = EXCLUDED.error").
+		Set("status = EXCLUDED.status").
+		Set("updated_at = current_timestamp").
+		Where("dead_letters.status <> ?", cons.DEAD_LETTER_STATUS_DISCARDED).
+		Exec(r.ctx)
+
+	if err != nil {
+		return err
+
+	} else if rows, err := result.RowsAffected(); err != nil || rows < 1 {
+		if err != nil {
+			return err
+		}
+
+		return cons.NO_ROWS_AFFECTED
+
+	}
+
+	return nil
+}
+
+func (r deadLettersRepositorie) Update(entitie entitie.DeadLettersEntitie, status ...string) error {
+	sqlb := r.db.NewUpdate().Model(&entitie).Where("id = ?", entitie.ID).OmitZero()
+
+	if len(status) > 0 {
+		sqlb = sqlb.Where("status IN (?)", bun.In(status))
+	}
+
+	result, err := sqlb.Exec(r.ctx)
+	if err != nil {
+		return err
+
+	} else if rows, err := result.RowsAffected(); err != nil || rows < 1 {
+		if err != nil {
+			return err
+		}
+
+		return cons.NO_ROWS_AFFECTED
+
+	}
+
+	return nil
+}
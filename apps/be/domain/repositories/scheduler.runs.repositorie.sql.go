@@ -0,0 +1,80 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type schedulerRunsRepositorie struct {
+	ctx     context.Context
+	db      *bun.DB
+	entitie *entitie.SchedulerRunsEntitie
+}
+
+func NewSchedulerRunsRepositorie(ctx context.Context, db *bun.DB) inf.ISchedulerRunsRepositorie {
+	return schedulerRunsRepositorie{ctx: ctx, db: db, entitie: new(entitie.SchedulerRunsEntitie)}
+}
+
+func (r schedulerRunsRepositorie) Find() *bun.SelectQuery {
+	return r.db.NewSelect().Model(r.entitie)
+}
+
+func (r schedulerRunsRepositorie) FindOne() *bun.SelectQuery {
+	return r.db.NewSelect().Model(r.entitie)
+}
+
+func (r schedulerRunsRepositorie) Insert(entitie entitie.SchedulerRunsEntitie, column string, dest ...any) error {
+	sqlb := r.db.NewInsert().Model(&entitie)
+
+	if column != "" && dest != nil {
+		result, err := sqlb.Returning(column).Exec(r.ctx, dest...)
+		if err != nil {
+			return err
+
+		} else if rows, err := result.RowsAffected(); err != nil || rows < 1 {
+			if err != nil {
+				return err
+			}
+
+			return cons.NO_ROWS_AFFECTED
+
+		}
+	} else {
+		result, err := sqlb.Exec(r.ctx)
+		if err != nil {
+			return err
+
+		} else if rows, err := result.RowsAffected(); err != nil || rows < 1 {
+			if err != nil {
+				return err
+			}
+
+			return cons.NO_ROWS_AFFECTED
+
+		}
+	}
+
+	return nil
+}
+
+func (r schedulerRunsRepositorie) Update(entitie entitie.SchedulerRunsEntitie) error {
+	result, err := r.db.NewUpdate().Model(&entitie).Where("id = ?", entitie.ID).OmitZero().Exec(r.ctx)
+	if err != nil {
+		return err
+
+	} else if rows, err := result.RowsAffected(); err != nil || rows < 1 {
+		if err != nil {
+			return err
+		}
+
+		return cons.NO_ROWS_AFFECTED
+
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"slices"
 
 	"github.com/meilisearch/meilisearch-go"
 
@@ -110,12 +111,12 @@ func (r usersMeilisearchRepositorie) Update(id string, value any) error {
 	return nil
 }
 
-func (r usersMeilisearchRepositorie) Delete(id string) error {
+func (r usersMeilisearchRepositorie) Delete(id string, deletedAt int64) error {
 	if err := r.meilisearch.CreateCollection("users", "id", r.doc); err != nil {
 		return err
 	}
 
-	if _, err := r.meilisearch.Delete("users", id); err != nil {
+	if _, err := r.meilisearch.Delete("users", id, deletedAt); err != nil {
 		return err
 	}
 
@@ -146,12 +147,24 @@ func (r usersMeilisearchRepositorie) BulkUpdate(value any) error {
 	return nil
 }
 
-func (r usersMeilisearchRepositorie) BulkDelete(ids ...string) error {
+func (r usersMeilisearchRepositorie) BulkDelete(deletedAt map[string]int64) error {
 	if err := r.meilisearch.CreateCollection("users", "id", r.doc); err != nil {
 		return err
 	}
 
-	if _, err := r.meilisearch.BulkDelete("users", ids...); err != nil {
+	if _, err := r.meilisearch.BulkDelete("users", deletedAt); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r usersMeilisearchRepositorie) BulkDestroy(ids ...string) error {
+	if err := r.meilisearch.CreateCollection("users", "id", r.doc); err != nil {
+		return err
+	}
+
+	if _, err := r.meilisearch.BulkDestroy("users", ids...); err != nil {
 		return err
 	}
 
@@ -190,25 +203,11 @@ func (r usersMeilisearchRepositorie) UpdateDisplayedAttributes(attributes ...str
 	return nil
 }
 
-func (r usersMeilisearchRepositorie) ListUsersDocuments(req dto.Request[dto.MeiliSearchDocumentsQuery]) (*opt.MeiliSearchDocuments[[]entitie.UsersDocument], error) {
+func (r usersMeilisearchRepositorie) ListUsersDocuments(req dto.Request[dto.MeiliSearchDocumentsQuery], role string) (*opt.MeiliSearchDocuments[[]entitie.UsersDocument], error) {
 	transform := helper.NewTransform()
 
 	usersDocumentsResult := new(opt.MeiliSearchDocuments[[]entitie.UsersDocument])
-	fields := []string{
-		"id",
-		"name",
-		"email",
-		"phone",
-		"date_of_birth",
-		"age",
-		"address",
-		"city",
-		"state",
-		"direction",
-		"country",
-		"postal_code",
-		"created_at",
-	}
+	fields, searchOn := usersDisplayedAttributes(role)
 
 	usersFilterDoc := new(dto.ListUsersFilterDTO)
 	if err := transform.ReqToRes(&req.Query.Filter, usersFilterDoc); err != nil {
@@ -310,11 +309,35 @@ func (r usersMeilisearchRepositorie) ListUsersDocuments(req dto.Request[dto.Meil
 			return nil, err
 		}
 
-		mlsSearchReq.AttributesToHighlight, err = r.meilisearch.GetSearchableAttributes("users")
+		searchableAttributes, err := r.meilisearch.GetSearchableAttributes("users")
 		if err != nil {
 			return nil, err
 		}
 
+		isSearchable := func(attribute string) bool {
+			return slices.Contains(searchableAttributes, "*") || slices.Contains(searchableAttributes, attribute)
+		}
+
+		for _, attribute := range fields {
+			if isSearchable(attribute) {
+				mlsSearchReq.AttributesToHighlight = append(mlsSearchReq.AttributesToHighlight, attribute)
+			}
+		}
+
+		for _, attribute := range searchOn {
+			if isSearchable(attribute) {
+				mlsSearchReq.AttributesToSearchOn = append(mlsSearchReq.AttributesToSearchOn, attribute)
+			}
+		}
+
+		if searchOn != nil && len(mlsSearchReq.AttributesToSearchOn) < 1 {
+			usersDocumentsResult.Results = []entitie.UsersDocument{}
+			usersDocumentsResult.Query = req.Query.Search
+			usersDocumentsResult.Limit = req.Query.Limit
+
+			return usersDocumentsResult, nil
+		}
+
 		usersSearchDocuments, err := r.Search(req.Query.Search, mlsSearchReq)
 		if err != nil {
 			return nil, err
@@ -330,3 +353,21 @@ func (r usersMeilisearchRepositorie) ListUsersDocuments(req dto.Request[dto.Meil
 
 	return usersDocumentsResult, nil
 }
+
+/**
+* Displayed attributes of users document per role, support only retrieve and search on masked personal data,
+* unknown role fallback to support attributes
+ */
+func usersDisplayedAttributes(role string) (fields []string, searchOn []string) {
+	common := []string{"id", "name", "age", "address", "city", "state", "direction", "country", "postal_code", "created_at"}
+
+	switch role {
+
+	case cons.ROLE_ADMIN:
+		return append(common, "email", "phone", "date_of_birth"), nil
+
+	default:
+		fields = append(common, "masked_email", "masked_phone", "masked_date_of_birth")
+		return fields, fields
+	}
+}
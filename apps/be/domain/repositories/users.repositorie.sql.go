@@ -6,6 +6,7 @@ import (
 
 	"github.com/guregu/null/v6/zero"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
 
 	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
 	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
@@ -98,6 +99,54 @@ func (r usersRepositorie) Update(entitie entitie.UsersEntitie, column string, de
 	return nil
 }
 
+func (r usersRepositorie) BulkUpdate(entitie entitie.UsersEntitie, ids ...string) error {
+	if len(ids) < 1 {
+		return nil
+	}
+
+	result, err := r.db.NewUpdate().Model(&entitie).Where("deleted_at IS NULL AND id = ANY(?)", pgdialect.Array(ids)).OmitZero().Exec(r.ctx)
+	if err != nil {
+		return err
+
+	} else if rows, err := result.RowsAffected(); err != nil || rows < 1 {
+		if err != nil {
+			return err
+		}
+
+		return cons.NO_ROWS_AFFECTED
+
+	}
+
+	return nil
+}
+
+/**
+* BulkFencedUpdate stamp the lease token on the rows and only update rows whose stored token is not newer,
+* so a holder that lost the lease can not overwrite what the next holder already wrote
+ */
+func (r usersRepositorie) BulkFencedUpdate(entitie entitie.UsersEntitie, token int64, ids ...string) error {
+	if len(ids) < 1 {
+		return nil
+	}
+
+	entitie.SyncToken = token
+
+	result, err := r.db.NewUpdate().Model(&entitie).Where("deleted_at IS NULL AND id = ANY(?) AND sync_token <= ?", pgdialect.Array(ids), token).OmitZero().Exec(r.ctx)
+	if err != nil {
+		return err
+
+	} else if rows, err := result.RowsAffected(); err != nil || rows < 1 {
+		if err != nil {
+			return err
+		}
+
+		return cons.NO_ROWS_AFFECTED
+
+	}
+
+	return nil
+}
+
 func (r usersRepositorie) Delete(id string, dest any) error {
 	r.entitie.DeletedAt = zero.TimeFrom(time.Now())
 	r.entitie.UpdatedAt = zero.TimeFrom(time.Now())
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/guregu/null/v6/zero"
+	"github.com/meilisearch/meilisearch-go"
+	"github.com/redis/go-redis/v9"
+	"github.com/uptrace/bun"
+	"github.com/wagslane/go-rabbitmq"
+
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	exception "github.com/restuwahyu13/go-fast-search/domain/exceptions"
+	repo "github.com/restuwahyu13/go-fast-search/domain/repositories"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+type apiKeysService struct {
+	env  dto.Request[dto.Environtment]
+	db   *bun.DB
+	rds  *redis.Client
+	amqp *rabbitmq.Conn
+	mls  meilisearch.ServiceManager
+}
+
+func NewApiKeysService(options dto.ServiceOptions) inf.IApiKeysService {
+	return apiKeysService{env: options.ENV, db: options.DB, rds: options.RDS, amqp: options.AMQP, mls: options.MLS}
+}
+
+func (s apiKeysService) CreateApiKey(ctx context.Context, req dto.Request[dto.CreateApiKeyDTO]) (res opt.Response) {
+	apiKeysException := exception.NewApiKeysException()
+	apiKeysRepositorie := repo.NewApiKeysRepositorie(ctx, s.db)
+
+	apiKeysEntitie := entitie.ApiKeysEntitie{}
+
+	if req.Body.ExpiredAt != "" {
+		expiredAt, err := time.Parse(time.RFC3339, req.Body.ExpiredAt)
+		if err != nil {
+			res.StatCode = http.StatusInternalServerError
+			res.ErrMsg = err.Error()
+
+			return
+		}
+
+		if !expiredAt.After(time.Now()) {
+			res.StatCode = http.StatusUnprocessableEntity
+			res.ErrMsg = apiKeysException.CreateApiKey("expired_at_passed")
+
+			return
+		}
+
+		apiKeysEntitie.ExpiredAt = zero.TimeFrom(expiredAt)
+	}
+
+	key, prefix, err := helper.GenerateApiKey()
+	if err != nil {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+	}
+
+	apiKeysEntitie.Name = req.Body.Name
+	apiKeysEntitie.Owner = req.Body.Owner
+	apiKeysEntitie.Scopes = req.Body.Scopes
+	apiKeysEntitie.CreatedBy = req.Body.CreatedBy
+	apiKeysEntitie.Prefix = prefix
+	apiKeysEntitie.Hash = helper.HashApiKey(key)
+
+	if err := apiKeysRepositorie.Insert(apiKeysEntitie, "id, created_at", &apiKeysEntitie.ID, &apiKeysEntitie.CreatedAt); err != nil {
+		if err != cons.NO_ROWS_AFFECTED {
+			res.StatCode = http.StatusInternalServerError
+			res.ErrMsg = err.Error()
+
+			return
+		}
+
+		res.StatCode = http.StatusPreconditionFailed
+		res.ErrMsg = apiKeysException.CreateApiKey("create_api_key_failed")
+
+		return
+	}
+
+	res.StatCode = http.StatusCreated
+	res.Message = "Success, the key is only shown once"
+	res.Data = opt.ApiKeyMetadata{ApiKeysEntitie: apiKeysEntitie, Key: key}
+
+	return
+}
+
+func (s apiKeysService) FindAllApiKeys(ctx context.Context, req dto.Request[dto.ListApiKeysDTO]) (res opt.Response) {
+	if req.Query.Limit < 1 {
+		req.Query.Limit = 10
+	}
+
+	if req.Query.Page < 1 {
+		req.Query.Page = 1
+	}
+
+	apiKeysRepositorie := repo.NewApiKeysRepositorie(ctx, s.db)
+	apiKeysEntities := []entitie.ApiKeysEntitie{}
+
+	sqlb := apiKeysRepositorie.Find()
+
+	if req.Query.Owner != "" {
+		sqlb = sqlb.Where("owner = ?", req.Query.Owner)
+	}
+
+	total, err := sqlb.Order("created_at DESC").
+		Limit(int(req.Query.Limit)).
+		Offset(int((req.Query.Page-1)*req.Query.Limit)).
+		ScanAndCount(ctx, &apiKeysEntities)
+
+	if err != nil {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Success"
+	res.Data = apiKeysEntities
+	res.Pagination = helper.Pagination(int(req.Query.Limit), int(req.Query.Page), total)
+
+	return
+}
+
+// RotateApiKey replace prefix and hash of an active api key, the previous key stop working immediately
+func (s apiKeysService) RotateApiKey(ctx context.Context, req dto.Request[dto.ApiKeyIDDTO]) (res opt.Response) {
+	apiKeysException := exception.NewApiKeysException()
+	apiKeysRepositorie := repo.NewApiKeysRepositorie(ctx, s.db)
+
+	apiKeysEntitie := entitie.ApiKeysEntitie{}
+
+	if res = s.findActive(ctx, apiKeysRepositorie, req.Param.ID, &apiKeysEntitie); res.StatCode >= http.StatusBadRequest {
+		return
+	}
+
+	key, prefix, err := helper.GenerateApiKey()
+	if err != nil {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+	}
+
+	apiKeysEntitie.Prefix = prefix
+	apiKeysEntitie.Hash = helper.HashApiKey(key)
+	apiKeysEntitie.RotatedAt = zero.TimeFrom(time.Now())
+	apiKeysEntitie.UpdatedAt = zero.TimeFrom(time.Now())
+
+	if err := apiKeysRepositorie.Update(apiKeysEntitie); err != nil {
+		if err != cons.NO_ROWS_AFFECTED {
+			res.StatCode = http.StatusInternalServerError
+			res.ErrMsg = err.Error()
+
+			return
+		}
+
+		res.StatCode = http.StatusConflict
+		res.ErrMsg = apiKeysException.ApiKey("api_key_revoked")
+
+		return
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Success, the key is only shown once"
+	res.Data = opt.ApiKeyMetadata{ApiKeysEntitie: apiKeysEntitie, Key: key}
+
+	return
+}
+
+func (s apiKeysService) RevokeApiKey(ctx context.Context, req dto.Request[dto.ApiKeyIDDTO]) (res opt.Response) {
+	apiKeysException := exception.NewApiKeysException()
+	apiKeysRepositorie := repo.NewApiKeysRepositorie(ctx, s.db)
+
+	apiKeysEntitie := entitie.ApiKeysEntitie{}
+
+	if res = s.findActive(ctx, apiKeysRepositorie, req.Param.ID, &apiKeysEntitie); res.StatCode >= http.StatusBadRequest {
+		return
+	}
+
+	apiKeysEntitie.RevokedAt = zero.TimeFrom(time.Now())
+	apiKeysEntitie.UpdatedAt = zero.TimeFrom(time.Now())
+
+	if err := apiKeysRepositorie.Update(apiKeysEntitie); err != nil {
+		if err != cons.NO_ROWS_AFFECTED {
+			res.StatCode = http.StatusInternalServerError
+			res.ErrMsg = err.Error()
+
+			return
+		}
+
+		res.StatCode = http.StatusConflict
+		res.ErrMsg = apiKeysException.ApiKey("api_key_revoked")
+
+		return
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Success"
+	res.Data = apiKeysEntitie
+
+	return
+}
+
+/**
+* AuthenticateApiKey lookup api key by prefix and compare sha256 hash in constant time,
+* every failure return the same message so caller can not tell unknown, revoked or expired key apart
+ */
+func (s apiKeysService) AuthenticateApiKey(ctx context.Context, req dto.Request[dto.AuthenticateApiKeyDTO]) (res opt.Response) {
+	apiKeysException := exception.NewApiKeysException()
+	apiKeysRepositorie := repo.NewApiKeysRepositorie(ctx, s.db)
+
+	res.StatCode = http.StatusUnauthorized
+	res.ErrMsg = apiKeysException.AuthenticateApiKey("invalid_api_key")
+
+	prefix, ok := helper.ParseApiKey(req.Body.Key)
+	if !ok {
+		return
+	}
+
+	apiKeysEntitie := entitie.ApiKeysEntitie{}
+
+	err := apiKeysRepositorie.FindOne().Column("id", "owner", "hash", "scopes", "expired_at", "revoked_at").
+		Where("prefix = ?", prefix).
+		Scan(ctx, &apiKeysEntitie)
+
+	if err != nil && err != sql.ErrNoRows {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+
+	} else if err == sql.ErrNoRows {
+		return
+	}
+
+	if subtle.ConstantTimeCompare([]byte(apiKeysEntitie.Hash), []byte(helper.HashApiKey(req.Body.Key))) != 1 {
+		return
+	}
+
+	if apiKeysEntitie.RevokedAt.Valid || (apiKeysEntitie.ExpiredAt.Valid && !apiKeysEntitie.ExpiredAt.Time.After(time.Now())) {
+		return
+	}
+
+	if err := apiKeysRepositorie.Touch(apiKeysEntitie.ID, time.Duration(time.Second*cons.API_KEY_TOUCH_INTERVAL)); err != nil {
+		pkg.Logrus(cons.ERROR, err)
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Success"
+	res.ErrMsg = ""
+	res.Data = opt.ApiKeyPrincipal{ID: apiKeysEntitie.ID, Owner: apiKeysEntitie.Owner, Scopes: apiKeysEntitie.Scopes}
+
+	return
+}
+
+func (s apiKeysService) findActive(ctx context.Context, apiKeysRepositorie inf.IApiKeysRepositorie, id string, apiKeysEntitie *entitie.ApiKeysEntitie) (res opt.Response) {
+	apiKeysException := exception.NewApiKeysException()
+
+	if err := apiKeysRepositorie.FindOne().Where("id = ?", id).Scan(ctx, apiKeysEntitie); err != nil {
+		if err != sql.ErrNoRows {
+			res.StatCode = http.StatusInternalServerError
+			res.ErrMsg = err.Error()
+
+			return
+		}
+
+		res.StatCode = http.StatusNotFound
+		res.ErrMsg = apiKeysException.ApiKey("api_key_notfound")
+
+		return
+	}
+
+	if apiKeysEntitie.RevokedAt.Valid {
+		res.StatCode = http.StatusConflict
+		res.ErrMsg = apiKeysException.ApiKey("api_key_revoked")
+
+		return
+	}
+
+	return
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	exception "github.com/restuwahyu13/go-fast-search/domain/exceptions"
+	repo "github.com/restuwahyu13/go-fast-search/domain/repositories"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type auditLogsService struct {
+	env dto.Request[dto.Environtment]
+	db  *bun.DB
+}
+
+func NewAuditLogsService(options dto.ServiceOptions) inf.IAuditLogsService {
+	return auditLogsService{env: options.ENV, db: options.DB}
+}
+
+func (s auditLogsService) FindAllAuditLogs(ctx context.Context, req dto.Request[dto.ListAuditLogsDTO]) (res opt.Response) {
+	auditLogsException := exception.NewAuditLogsException()
+
+	if req.Query.Limit < 1 {
+		req.Query.Limit = 10
+	}
+
+	if req.Query.Page < 1 {
+		req.Query.Page = 1
+	}
+
+	auditLogsRepositorie := repo.NewAuditLogsRepositorie(ctx, s.db)
+	auditLogsEntities := []entitie.AuditLogsEntitie{}
+
+	sqlb := auditLogsRepositorie.Find()
+
+	if req.Query.Actor != "" {
+		sqlb = sqlb.Where("actor = ?", req.Query.Actor)
+	}
+
+	if req.Query.TargetID != "" {
+		sqlb = sqlb.Where("target_id = ?", req.Query.TargetID)
+	}
+
+	if req.Query.Action != "" {
+		sqlb = sqlb.Where("action = ?", req.Query.Action)
+	}
+
+	from, to := time.Time{}, time.Time{}
+
+	if req.Query.From != "" {
+		from, _ = time.Parse(time.RFC3339, req.Query.From)
+		sqlb = sqlb.Where("occurred_at >= ?", from)
+	}
+
+	if req.Query.To != "" {
+		to, _ = time.Parse(time.RFC3339, req.Query.To)
+		sqlb = sqlb.Where("occurred_at <= ?", to)
+	}
+
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		res.StatCode = http.StatusUnprocessableEntity
+		res.ErrMsg = auditLogsException.FindAllAuditLogs("audit_logs_range_invalid")
+
+		return
+	}
+
+	total, err := sqlb.Order("occurred_at DESC", "id DESC").
+		Limit(int(req.Query.Limit)).
+		Offset(int((req.Query.Page-1)*req.Query.Limit)).
+		ScanAndCount(ctx, &auditLogsEntities)
+
+	if err != nil {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Success"
+	res.Data = auditLogsEntities
+	res.Pagination = helper.Pagination(int(req.Query.Limit), int(req.Query.Page), total)
+
+	return
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/guregu/null/v6/zero"
+	"github.com/meilisearch/meilisearch-go"
+	"github.com/redis/go-redis/v9"
+	"github.com/uptrace/bun"
+	"github.com/wagslane/go-rabbitmq"
+
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	exception "github.com/restuwahyu13/go-fast-search/domain/exceptions"
+	repo "github.com/restuwahyu13/go-fast-search/domain/repositories"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+type authService struct {
+	env  dto.Request[dto.Environtment]
+	db   *bun.DB
+	rds  *redis.Client
+	amqp *rabbitmq.Conn
+	mls  meilisearch.ServiceManager
+}
+
+func NewAuthService(options dto.ServiceOptions) inf.IAuthService {
+	return authService{env: options.ENV, db: options.DB, rds: options.RDS, amqp: options.AMQP, mls: options.MLS}
+}
+
+func (s authService) Login(ctx context.Context, req dto.Request[dto.LoginDTO]) (res opt.Response) {
+	authException := exception.NewAuthException()
+	accountsRepositorie := repo.NewAccountsRepositorie(ctx, s.db)
+
+	accountsEntitie := entitie.AccountsEntitie{}
+
+	err := accountsRepositorie.FindOne().Column("id", "username", "password", "role").
+		Where("deleted_at IS NULL").
+		Where("is_active = ?", cons.TRUE).
+		Where("username = ?", req.Body.Username).
+		Scan(ctx, &accountsEntitie)
+
+	if err != nil && err != sql.ErrNoRows {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+	}
+
+	if !helper.ComparePassword(accountsEntitie.Password, req.Body.Password) {
+		res.StatCode = http.StatusUnauthorized
+		res.ErrMsg = authException.Login("invalid_credentials")
+
+		return
+	}
+
+	claim := map[string]any{
+		"id":                  accountsEntitie.ID,
+		"username":            accountsEntitie.Username,
+		cons.AUTH_ROLE:        accountsEntitie.Role,
+		cons.AUTH_PERMISSIONS: helper.Permissions(accountsEntitie.Role),
+	}
+
+	sessionMetadata, err := pkg.NewSession(ctx, &s.env.Config, s.rds).Create(accountsEntitie.ID, claim)
+	if err != nil {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+	}
+
+	accountsEntitie.Password = ""
+	accountsEntitie.Role = ""
+	accountsEntitie.LastLoginAt = zero.TimeFrom(time.Now())
+
+	if err := accountsRepositorie.Update(accountsEntitie); err != nil {
+		pkg.Logrus(cons.ERROR, err)
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Success"
+	res.Data = sessionMetadata.Token
+
+	return
+}
+
+func (s authService) Refresh(ctx context.Context, req dto.Request[dto.RefreshTokenDTO]) (res opt.Response) {
+	authException := exception.NewAuthException()
+	accountsRepositorie := repo.NewAccountsRepositorie(ctx, s.db)
+	session := pkg.NewSession(ctx, &s.env.Config, s.rds)
+
+	sessionMetadata, err := session.Refresh(req.Body.RefreshToken)
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+
+		res.StatCode = http.StatusUnauthorized
+		res.ErrMsg = authException.Refresh("invalid_refresh_token")
+
+		return
+	}
+
+	accountsEntitie := entitie.AccountsEntitie{}
+
+	err = accountsRepositorie.FindOne().Column("id").
+		Where("deleted_at IS NULL").
+		Where("is_active = ?", cons.TRUE).
+		Where("id = ?", sessionMetadata.Subject).
+		Scan(ctx, &accountsEntitie)
+
+	if err != nil && err != sql.ErrNoRows {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+
+	} else if err == sql.ErrNoRows {
+		if err := session.Revoke(sessionMetadata.Subject, sessionMetadata.Session); err != nil {
+			pkg.Logrus(cons.ERROR, err)
+		}
+
+		res.StatCode = http.StatusUnauthorized
+		res.ErrMsg = authException.Refresh("invalid_refresh_token")
+
+		return
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Success"
+	res.Data = sessionMetadata.Token
+
+	return
+}
+
+func (s authService) Logout(ctx context.Context, req dto.Request[dto.LogoutDTO]) (res opt.Response) {
+	if err := pkg.NewSession(ctx, &s.env.Config, s.rds).Revoke(req.Body.UserID, req.Body.SessionID); err != nil {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Success"
+
+	return
+}
+
+func (s authService) Jwks(ctx context.Context) (res opt.Response) {
+	jwkSet, err := pkg.NewKeyset(ctx, &s.env.Config, s.rds).Jwks()
+	if err != nil {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Success"
+	res.Data = jwkSet
+
+	return
+}
+
+func (s authService) RevokeSessions(ctx context.Context, req dto.Request[dto.RevokeSessionsDTO]) (res opt.Response) {
+	authException := exception.NewAuthException()
+	accountsRepositorie := repo.NewAccountsRepositorie(ctx, s.db)
+
+	accountsEntitie := entitie.AccountsEntitie{}
+
+	err := accountsRepositorie.FindOne().Column("id").
+		Where("id = ?", req.Param.ID).
+		Scan(ctx, &accountsEntitie)
+
+	if err != nil && err != sql.ErrNoRows {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+
+	} else if err == sql.ErrNoRows {
+		res.StatCode = http.StatusNotFound
+		res.ErrMsg = authException.RevokeSessions("account_notfound")
+
+		return
+	}
+
+	total, err := pkg.NewSession(ctx, &s.env.Config, s.rds).RevokeAll(accountsEntitie.ID)
+	if err != nil {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Success"
+	res.Data = opt.SessionsAffected{Total: total}
+
+	return
+}
+
+func (s authService) CreateAccount(ctx context.Context, req dto.Request[dto.CreateAccountDTO]) (res opt.Response) {
+	authException := exception.NewAuthException()
+	accountsRepositorie := repo.NewAccountsRepositorie(ctx, s.db)
+
+	accountsEntitie := entitie.AccountsEntitie{}
+
+	err := accountsRepositorie.FindOne().Column("id").
+		Where("username = ?", req.Body.Username).
+		Scan(ctx, &accountsEntitie)
+
+	if err != nil && err != sql.ErrNoRows {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+
+	} else if err != sql.ErrNoRows {
+		res.StatCode = http.StatusConflict
+		res.ErrMsg = authException.CreateAccount("account_exists")
+
+		return
+	}
+
+	password, err := helper.HashPassword(req.Body.Password)
+	if err != nil {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+	}
+
+	accountsEntitie.Username = req.Body.Username
+	accountsEntitie.Password = password
+	accountsEntitie.Role = req.Body.Role
+	accountsEntitie.IsActive = cons.TRUE
+
+	if err := accountsRepositorie.Insert(accountsEntitie, "id, created_at", &accountsEntitie.ID, &accountsEntitie.CreatedAt); err != nil {
+		if err != cons.NO_ROWS_AFFECTED {
+			res.StatCode = http.StatusInternalServerError
+			res.ErrMsg = err.Error()
+
+			return
+		}
+
+		res.StatCode = http.StatusPreconditionFailed
+		res.ErrMsg = authException.CreateAccount("create_account_failed")
+
+		return
+	}
+
+	accountsEntitie.Password = ""
+
+	res.StatCode = http.StatusCreated
+	res.Message = "Success"
+	res.Data = accountsEntitie
+
+	return
+}
+
+/**
+* Role is carried in the claims of issued token, so every session of the account is revoked
+* and the new role is applied on next login
+ */
+func (s authService) UpdateAccountRole(ctx context.Context, req dto.Request[dto.UpdateAccountRoleDTO]) (res opt.Response) {
+	authException := exception.NewAuthException()
+	accountsRepositorie := repo.NewAccountsRepositorie(ctx, s.db)
+
+	accountsEntitie := entitie.AccountsEntitie{}
+
+	err := accountsRepositorie.FindOne().Column("id").
+		Where("deleted_at IS NULL").
+		Where("id = ?", req.Body.ID).
+		Scan(ctx, &accountsEntitie)
+
+	if err != nil && err != sql.ErrNoRows {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+
+	} else if err == sql.ErrNoRows {
+		res.StatCode = http.StatusNotFound
+		res.ErrMsg = authException.UpdateAccountRole("account_notfound")
+
+		return
+	}
+
+	accountsEntitie.Role = req.Body.Role
+	accountsEntitie.UpdatedAt = zero.TimeFrom(time.Now())
+
+	if err := accountsRepositorie.Update(accountsEntitie); err != nil {
+		if err != cons.NO_ROWS_AFFECTED {
+			res.StatCode = http.StatusInternalServerError
+			res.ErrMsg = err.Error()
+
+			return
+		}
+
+		res.StatCode = http.StatusPreconditionFailed
+		res.ErrMsg = authException.UpdateAccountRole("update_account_role_failed")
+
+		return
+	}
+
+	if _, err := pkg.NewSession(ctx, &s.env.Config, s.rds).RevokeAll(accountsEntitie.ID); err != nil {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Success"
+	res.Data = accountsEntitie
+
+	return
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/guregu/null/v6/zero"
+	"github.com/redis/go-redis/v9"
+	"github.com/uptrace/bun"
+	"github.com/wagslane/go-rabbitmq"
+
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	exception "github.com/restuwahyu13/go-fast-search/domain/exceptions"
+	repo "github.com/restuwahyu13/go-fast-search/domain/repositories"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+type deadLettersService struct {
+	env  dto.Request[dto.Environtment]
+	db   *bun.DB
+	rds  *redis.Client
+	amqp *rabbitmq.Conn
+}
+
+func NewDeadLettersService(options dto.ServiceOptions) inf.IDeadLettersService {
+	return deadLettersService{env: options.ENV, db: options.DB, rds: options.RDS, amqp: options.AMQP}
+}
+
+func (s deadLettersService) FindAllDeadLetters(ctx context.Context, req dto.Request[dto.ListDeadLettersDTO]) (res opt.Response) {
+	if req.Query.Limit < 1 {
+		req.Query.Limit = 10
+	}
+
+	if req.Query.Page < 1 {
+		req.Query.Page = 1
+	}
+
+	deadLettersRepositorie := repo.NewDeadLettersRepositorie(ctx, s.db)
+	deadLettersEntities := []entitie.DeadLettersEntitie{}
+
+	sqlb := deadLettersRepositorie.Find()
+
+	if req.Query.DocumentID != "" {
+		sqlb = sqlb.Where("document_id = ?", req.Query.DocumentID)
+	}
+
+	if req.Query.Status != "" {
+		sqlb = sqlb.Where("status = ?", req.Query.Status)
+	}
+
+	total, err := sqlb.Order("created_at DESC").
+		Limit(int(req.Query.Limit)).
+		Offset(int((req.Query.Page-1)*req.Query.Limit)).
+		ScanAndCount(ctx, &deadLettersEntities)
+
+	if err != nil {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Success"
+	res.Data = deadLettersEntities
+	res.Pagination = helper.Pagination(int(req.Query.Limit), int(req.Query.Page), total)
+
+	return
+}
+
+func (s deadLettersService) FindDeadLetter(ctx context.Context, req dto.Request[dto.DeadLetterIDDTO]) (res opt.Response) {
+	deadLettersException := exception.NewDeadLettersException()
+	deadLettersRepositorie := repo.NewDeadLettersRepositorie(ctx, s.db)
+
+	deadLettersEntitie := entitie.DeadLettersEntitie{}
+
+	if err := deadLettersRepositorie.FindOne().Where("id = ?", req.Param.ID).Scan(ctx, &deadLettersEntitie); err != nil {
+		if err != sql.ErrNoRows {
+			res.StatCode = http.StatusInternalServerError
+			res.ErrMsg = err.Error()
+
+			return
+		}
+
+		res.StatCode = http.StatusNotFound
+		res.ErrMsg = deadLettersException.FindDeadLetter("dead_letter_notfound")
+
+		return
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Success"
+	res.Data = deadLettersEntitie
+
+	return
+}
+
+func (s deadLettersService) ReplayDeadLetter(ctx context.Context, req dto.Request[dto.DeadLetterIDDTO]) (res opt.Response) {
+	deadLettersException := exception.NewDeadLettersException()
+	deadLettersRepositorie := repo.NewDeadLettersRepositorie(ctx, s.db)
+
+	deadLettersEntitie := entitie.DeadLettersEntitie{}
+
+	if err := deadLettersRepositorie.FindOne().Where("id = ?", req.Param.ID).Scan(ctx, &deadLettersEntitie); err != nil {
+		if err != sql.ErrNoRows {
+			res.StatCode = http.StatusInternalServerError
+			res.ErrMsg = err.Error()
+
+			return
+		}
+
+		res.StatCode = http.StatusNotFound
+		res.ErrMsg = deadLettersException.ReplayDeadLetter("dead_letter_notfound")
+
+		return
+	}
+
+	if deadLettersEntitie.Status != cons.DEAD_LETTER_STATUS_PARKED {
+		res.StatCode = http.StatusConflict
+		res.ErrMsg = deadLettersException.ReplayDeadLetter("dead_letter_not_parked")
+
+		return
+	}
+
+	if err := s.replay(ctx, deadLettersRepositorie, deadLettersEntitie); err != nil {
+		if err != cons.NO_ROWS_AFFECTED {
+			res.StatCode = http.StatusInternalServerError
+			res.ErrMsg = err.Error()
+
+			return
+		}
+
+		res.StatCode = http.StatusConflict
+		res.ErrMsg = deadLettersException.ReplayDeadLetter("dead_letter_not_parked")
+
+		return
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Dead letter is replayed"
+	res.Data = opt.DeadLettersAffected{Total: 1}
+
+	return
+}
+
+func (s deadLettersService) ReplayAllDeadLetters(ctx context.Context, req dto.Request[dto.DeadLettersFilterDTO]) (res opt.Response) {
+	deadLettersRepositorie := repo.NewDeadLettersRepositorie(ctx, s.db)
+	total := 0
+
+	/**
+	* Replayed rows leave the parked status, so every batch picks up the next
+	* parked rows until nothing is left
+	 */
+	for {
+		deadLettersEntities := []entitie.DeadLettersEntitie{}
+
+		sqlb := deadLettersRepositorie.Find().Where("status = ?", cons.DEAD_LETTER_STATUS_PARKED)
+
+		if req.Query.DocumentID != "" {
+			sqlb = sqlb.Where("document_id = ?", req.Query.DocumentID)
+		}
+
+		if err := sqlb.Order("created_at ASC").Limit(100).Scan(ctx, &deadLettersEntities); err != nil {
+			res.StatCode = http.StatusInternalServerError
+			res.ErrMsg = err.Error()
+
+			return
+		}
+
+		if len(deadLettersEntities) < 1 {
+			break
+		}
+
+		for _, deadLettersEntitie := range deadLettersEntities {
+			if err := s.replay(ctx, deadLettersRepositorie, deadLettersEntitie); err != nil {
+				if err == cons.NO_ROWS_AFFECTED {
+					continue
+				}
+
+				res.StatCode = http.StatusInternalServerError
+				res.ErrMsg = err.Error()
+
+				return
+			}
+
+			total++
+		}
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Dead letters are replayed"
+	res.Data = opt.DeadLettersAffected{Total: total}
+
+	return
+}
+
+func (s deadLettersService) DiscardDeadLetter(ctx context.Context, req dto.Request[dto.DeadLetterIDDTO]) (res opt.Response) {
+	deadLettersException := exception.NewDeadLettersException()
+	deadLettersRepositorie := repo.NewDeadLettersRepositorie(ctx, s.db)
+
+	deadLettersEntitie := entitie.DeadLettersEntitie{}
+
+	if err := deadLettersRepositorie.FindOne().Column("id").Where("id = ?", req.Param.ID).Scan(ctx, &deadLettersEntitie); err != nil {
+		if err != sql.ErrNoRows {
+			res.StatCode = http.StatusInternalServerError
+			res.ErrMsg = err.Error()
+
+			return
+		}
+
+		res.StatCode = http.StatusNotFound
+		res.ErrMsg = deadLettersException.DiscardDeadLetter("dead_letter_notfound")
+
+		return
+	}
+
+	deadLettersEntitie.Status = cons.DEAD_LETTER_STATUS_DISCARDED
+	deadLettersEntitie.UpdatedAt = zero.TimeFrom(time.Now())
+
+	if err := deadLettersRepositorie.Update(deadLettersEntitie, cons.DEAD_LETTER_STATUS_PARKED, cons.DEAD_LETTER_STATUS_RETRYING); err != nil {
+		if err != cons.NO_ROWS_AFFECTED {
+			res.StatCode = http.StatusInternalServerError
+			res.ErrMsg = err.Error()
+
+			return
+		}
+
+		res.StatCode = http.StatusConflict
+		res.ErrMsg = deadLettersException.DiscardDeadLetter("dead_letter_closed")
+
+		return
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Dead letter is discarded"
+	res.Data = opt.DeadLettersAffected{Total: 1}
+
+	return
+}
+
+func (s deadLettersService) DiscardAllDeadLetters(ctx context.Context, req dto.Request[dto.DeadLettersFilterDTO]) (res opt.Response) {
+	sqlb := s.db.NewUpdate().Model((*entitie.DeadLettersEntitie)(nil)).
+		Set("status = ?", cons.DEAD_LETTER_STATUS_DISCARDED).
+		Set("updated_at = current_timestamp").
+		Where("status IN (?)", bun.In([]string{cons.DEAD_LETTER_STATUS_PARKED, cons.DEAD_LETTER_STATUS_RETRYING}))
+
+	if req.Query.DocumentID != "" {
+		sqlb = sqlb.Where("document_id = ?", req.Query.DocumentID)
+	}
+
+	result, err := sqlb.Exec(ctx)
+	if err != nil {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+	}
+
+	total, err := result.RowsAffected()
+	if err != nil {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Dead letters are discarded"
+	res.Data = opt.DeadLettersAffected{Total: int(total)}
+
+	return
+}
+
+/**
+* Mark the row as replayed before publishing, so a replay that fails again fast is mirrored back
+* as retrying by the dead letter queue worker instead of being overwritten here
+ */
+func (s deadLettersService) replay(ctx context.Context, deadLettersRepositorie inf.IDeadLettersRepositorie, deadLettersEntitie entitie.DeadLettersEntitie) error {
+	now := time.Now()
+
+	if err := deadLettersRepositorie.Update(entitie.DeadLettersEntitie{
+		ID:         deadLettersEntitie.ID,
+		Status:     cons.DEAD_LETTER_STATUS_REPLAYED,
+		UpdatedAt:  zero.TimeFrom(now),
+		ReplayedAt: zero.TimeFrom(now),
+	}, cons.DEAD_LETTER_STATUS_PARKED); err != nil {
+		return err
+	}
+
+	broker := pkg.NewBroker(ctx, s.env, s.amqp, s.rds)
+
+	amqp_req := dto.Request[dto.BrokerOptions]{}
+	amqp_req.Option.ExchangeName = deadLettersEntitie.Exchange
+	amqp_req.Option.ExchangeType = cons.EXCHANGE_TYPE_DIRECT
+	amqp_req.Option.QueueName = deadLettersEntitie.Queue
+	amqp_req.Option.Body = deadLettersEntitie.Payload
+
+	if deadLettersEntitie.Exchange == cons.EXCHANGE_NAME_SEARCH && deadLettersEntitie.DocumentID != "" {
+		amqp_req.Option.QueueName = helper.PartitionQueue(cons.QUEUE_NAME_SEARCH_PARTITION, deadLettersEntitie.DocumentID, s.env.Config.RABBITMQ.PARTITIONS)
+	}
+	amqp_req.Option.Args = map[string]any{
+		cons.X_DEAD_LETTER_ID: deadLettersEntitie.ID,
+		cons.X_RETRY_ATTEMPT:  0,
+	}
+
+	if err := broker.Publisher(amqp_req); err != nil {
+		if _, rollbackErr := s.db.NewUpdate().Model((*entitie.DeadLettersEntitie)(nil)).
+			Set("status = ?", cons.DEAD_LETTER_STATUS_PARKED).
+			Set("replayed_at = NULL").
+			Where("id = ?", deadLettersEntitie.ID).
+			Exec(ctx); rollbackErr != nil {
+			pkg.Logrus(cons.ERROR, rollbackErr)
+		}
+
+		return err
+	}
+
+	pkg.Logrus(cons.INFO, "Dead letter %s is replayed to queue %s", deadLettersEntitie.ID, amqp_req.Option.QueueName)
+	return nil
+}
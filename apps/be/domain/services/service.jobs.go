@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	exception "github.com/restuwahyu13/go-fast-search/domain/exceptions"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type jobsService struct {
+	registry inf.IJobControl
+}
+
+func NewJobsService(options dto.RegistryServiceOptions[inf.IJobControl]) inf.IJobsService {
+	return jobsService{registry: options.REGISTRY}
+}
+
+func (s jobsService) FindAllJobs(ctx context.Context) (res opt.Response) {
+	res.StatCode = http.StatusOK
+	res.Message = "Success"
+	res.Data = s.registry.Jobs()
+
+	return
+}
+
+func (s jobsService) FindAllJobRuns(ctx context.Context) (res opt.Response) {
+	res.StatCode = http.StatusOK
+	res.Message = "Success"
+	res.Data = s.registry.Runs()
+
+	return
+}
+
+func (s jobsService) TriggerJob(ctx context.Context, req dto.Request[dto.JobNameDTO]) (res opt.Response) {
+	if err := s.registry.Trigger(req.Param.Name); err != nil {
+		return s.jobError(err)
+	}
+
+	res.StatCode = http.StatusAccepted
+	res.Message = "Job is triggered"
+
+	return
+}
+
+func (s jobsService) PauseJob(ctx context.Context, req dto.Request[dto.JobNameDTO]) (res opt.Response) {
+	if err := s.registry.Pause(req.Param.Name); err != nil {
+		return s.jobError(err)
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Job is paused"
+
+	return
+}
+
+func (s jobsService) ResumeJob(ctx context.Context, req dto.Request[dto.JobNameDTO]) (res opt.Response) {
+	if err := s.registry.Resume(req.Param.Name); err != nil {
+		return s.jobError(err)
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Job is resumed"
+
+	return
+}
+
+func (s jobsService) jobError(err error) (res opt.Response) {
+	jobsException := exception.NewJobsException()
+
+	switch {
+
+	case errors.Is(err, cons.JOB_NOT_FOUND):
+		res.StatCode = http.StatusNotFound
+		res.ErrMsg = jobsException.JobControl("job_notfound")
+
+	case errors.Is(err, cons.JOB_STANDBY):
+		res.StatCode = http.StatusConflict
+		res.ErrMsg = jobsException.JobControl("job_standby")
+
+	case errors.Is(err, cons.JOB_ALREADY_RUNNING):
+		res.StatCode = http.StatusConflict
+		res.ErrMsg = jobsException.JobControl("job_running")
+
+	case errors.Is(err, cons.JOB_NOT_TRIGGERABLE):
+		res.StatCode = http.StatusBadRequest
+		res.ErrMsg = jobsException.JobControl("job_not_triggerable")
+
+	default:
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+	}
+
+	return
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/meilisearch/meilisearch-go"
+	"github.com/redis/go-redis/v9"
+	"github.com/uptrace/bun"
+	"github.com/wagslane/go-rabbitmq"
+
+	exception "github.com/restuwahyu13/go-fast-search/domain/exceptions"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+type leasesService struct {
+	env  dto.Request[dto.Environtment]
+	db   *bun.DB
+	rds  *redis.Client
+	amqp *rabbitmq.Conn
+	mls  meilisearch.ServiceManager
+}
+
+func NewLeasesService(options dto.ServiceOptions) inf.ILeasesService {
+	return leasesService{env: options.ENV, db: options.DB, rds: options.RDS, amqp: options.AMQP, mls: options.MLS}
+}
+
+func (s leasesService) FindAllLeases(ctx context.Context) (res opt.Response) {
+	leasesMetadata := []opt.LeaseMetadata{}
+
+	names, err := s.rds.SMembers(ctx, cons.LEASE_REGISTRY_KEY).Result()
+	if err != nil {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		leaseMetadata, err := pkg.NewLease(ctx, s.rds, dto.LeaseOptions{Name: name}).Status()
+		if err != nil {
+			res.StatCode = http.StatusInternalServerError
+			res.ErrMsg = err.Error()
+
+			return
+		}
+
+		leasesMetadata = append(leasesMetadata, *leaseMetadata)
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Success"
+	res.Data = leasesMetadata
+
+	return
+}
+
+func (s leasesService) FindLease(ctx context.Context, req dto.Request[dto.FindLeaseDTO]) (res opt.Response) {
+	leasesException := exception.NewLeasesException()
+
+	isMember, err := s.rds.SIsMember(ctx, cons.LEASE_REGISTRY_KEY, req.Param.Name).Result()
+	if err != nil {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+	}
+
+	if !isMember {
+		res.StatCode = http.StatusNotFound
+		res.ErrMsg = leasesException.FindLease("lease_notfound")
+
+		return
+	}
+
+	leaseMetadata, err := pkg.NewLease(ctx, s.rds, dto.LeaseOptions{Name: req.Param.Name}).Status()
+	if err != nil {
+		res.StatCode = http.StatusInternalServerError
+		res.ErrMsg = err.Error()
+
+		return
+	}
+
+	res.StatCode = http.StatusOK
+	res.Message = "Success"
+	res.Data = leaseMetadata
+
+	return
+}
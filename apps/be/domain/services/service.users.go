@@ -101,6 +101,9 @@ func (s usersService) CreateUsers(ctx context.Context, req dto.Request[dto.Creat
 	usersDocEntitie.Email = usersEntitie.Email
 	usersDocEntitie.Phone = usersEntitie.Phone
 	usersDocEntitie.DateOfBirth = usersEntitie.DateOfBirth
+	usersDocEntitie.MaskedEmail = helper.MaskEmail(usersEntitie.Email)
+	usersDocEntitie.MaskedPhone = helper.MaskPhone(usersEntitie.Phone)
+	usersDocEntitie.MaskedDateOfBirth = helper.MaskDate(usersEntitie.DateOfBirth)
 	usersDocEntitie.Age = usersEntitie.Age
 	usersDocEntitie.Address = usersEntitie.Address
 	usersDocEntitie.City = usersEntitie.City
@@ -110,15 +113,17 @@ func (s usersService) CreateUsers(ctx context.Context, req dto.Request[dto.Creat
 	usersDocEntitie.PostalCode = usersEntitie.PostalCode
 	usersDocEntitie.CreatedAt = createdAtUnix
 
-	amqp := pkg.NewRabbitMQ(ctx, s.amqp)
+	broker := pkg.NewBroker(ctx, s.env, s.amqp, s.rds)
 
-	if err := helper.MeiliSearchPublisher[entitie.UsersDocument](amqp, s.env.Config.RABBITMQ.SECRET, nil, usersDocEntitie, cons.FALSE, cons.INSERT); err != nil {
+	if err := helper.MeiliSearchPublisher[entitie.UsersDocument](ctx, broker, s.env, nil, usersDocEntitie, cons.FALSE, cons.INSERT); err != nil {
 		res.StatCode = http.StatusInternalServerError
 		res.ErrMsg = err.Error()
 
 		return
 	}
 
+	s.audit(ctx, broker, dto.AuditLog{Action: cons.AUDIT_ACTION_CREATE, TargetID: usersEntitie.ID}, nil, usersEntitie)
+
 	res.StatCode = http.StatusOK
 	res.Message = "Success to create new users"
 
@@ -130,11 +135,12 @@ func (s usersService) UpdateUsers(ctx context.Context, req dto.Request[dto.Updat
 	usersRepositorie := repo.NewUsersRepositorie(ctx, s.db)
 
 	usersEntitie := entitie.UsersEntitie{}
+	beforeUsersEntitie := entitie.UsersEntitie{}
 
-	err := usersRepositorie.FindOne().Column("id").
+	err := usersRepositorie.FindOne().
 		Where("deleted_at IS NULL").
 		Where("id = ?", req.Body.ID).
-		Scan(ctx, &usersEntitie)
+		Scan(ctx, &beforeUsersEntitie)
 
 	if err != nil && err != sql.ErrNoRows {
 		res.StatCode = http.StatusInternalServerError
@@ -150,6 +156,7 @@ func (s usersService) UpdateUsers(ctx context.Context, req dto.Request[dto.Updat
 
 	}
 
+	usersEntitie.ID = beforeUsersEntitie.ID
 	usersEntitie.Name = req.Body.Name
 	usersEntitie.Email = req.Body.Email
 	usersEntitie.Phone = req.Body.Phone
@@ -189,6 +196,9 @@ func (s usersService) UpdateUsers(ctx context.Context, req dto.Request[dto.Updat
 	usersDocEntitie.Email = usersEntitie.Email
 	usersDocEntitie.Phone = usersEntitie.Phone
 	usersDocEntitie.DateOfBirth = usersEntitie.DateOfBirth
+	usersDocEntitie.MaskedEmail = helper.MaskEmail(usersEntitie.Email)
+	usersDocEntitie.MaskedPhone = helper.MaskPhone(usersEntitie.Phone)
+	usersDocEntitie.MaskedDateOfBirth = helper.MaskDate(usersEntitie.DateOfBirth)
 	usersDocEntitie.Age = usersEntitie.Age
 	usersDocEntitie.Address = usersEntitie.Address
 	usersDocEntitie.City = usersEntitie.City
@@ -198,15 +208,17 @@ func (s usersService) UpdateUsers(ctx context.Context, req dto.Request[dto.Updat
 	usersDocEntitie.PostalCode = usersEntitie.PostalCode
 	usersDocEntitie.UpdatedAt = updatedAtUnix
 
-	amqp := pkg.NewRabbitMQ(ctx, s.amqp)
+	broker := pkg.NewBroker(ctx, s.env, s.amqp, s.rds)
 
-	if err := helper.MeiliSearchPublisher[entitie.UsersDocument](amqp, s.env.Config.RABBITMQ.SECRET, req.Body.ID, usersDocEntitie, cons.FALSE, cons.UPDATE); err != nil {
+	if err := helper.MeiliSearchPublisher[entitie.UsersDocument](ctx, broker, s.env, req.Body.ID, usersDocEntitie, cons.FALSE, cons.UPDATE); err != nil {
 		res.StatCode = http.StatusInternalServerError
 		res.ErrMsg = err.Error()
 
 		return
 	}
 
+	s.audit(ctx, broker, dto.AuditLog{Action: cons.AUDIT_ACTION_UPDATE, TargetID: usersEntitie.ID}, beforeUsersEntitie, usersEntitie)
+
 	res.StatCode = http.StatusOK
 	res.Message = "Success to update users"
 	res.Data = usersEntitie
@@ -227,10 +239,24 @@ func (s usersService) FindAllUsers(ctx context.Context, req dto.Request[dto.Meil
 		req.Query.Sort = "desc"
 	}
 
+	broker := pkg.NewBroker(ctx, s.env, s.amqp, s.rds)
+
+	// Search term is hashed as a whole since it may carry personal data
+	s.audit(ctx, broker, dto.AuditLog{Action: cons.AUDIT_ACTION_SEARCH, Metadata: map[string]any{
+		"search":            helper.AuditHash(s.env, req.Query.Search),
+		"filter":            helper.AuditFields(s.env, req.Query.Filter),
+		"sort":              req.Query.Sort,
+		"matching_strategy": req.Query.MatchingStrategy,
+		"limit":             req.Query.Limit,
+		"page":              req.Query.Page,
+	}}, nil, nil)
+
 	req.Query.Page = (req.Query.Page - 1) * req.Query.Limit
 
+	role, _ := ctx.Value(cons.AUTH_ROLE).(string)
+
 	usersRepositorie := repo.NewUsersMeilisearchRepositorie(ctx, s.mls)
-	resultUsersDocuments, err := usersRepositorie.ListUsersDocuments(req)
+	resultUsersDocuments, err := usersRepositorie.ListUsersDocuments(req, role)
 	if err != nil {
 		if !strings.Contains(err.Error(), "not found") {
 			res.StatCode = http.StatusInternalServerError
@@ -252,3 +278,23 @@ func (s usersService) FindAllUsers(ctx context.Context, req dto.Request[dto.Meil
 
 	return
 }
+
+/**
+* Audit entry is published once the change is committed, failing to publish it is logged and never fail the request
+ */
+func (s usersService) audit(ctx context.Context, broker inf.IBroker, req dto.AuditLog, before, after any) {
+	req.TargetType = cons.AUDIT_TARGET_USERS
+
+	if before != nil || after != nil {
+		changes, err := helper.AuditChanges(s.env, before, after)
+		if err != nil {
+			pkg.Logrus(cons.ERROR, err)
+		}
+
+		req.Changes = changes
+	}
+
+	if err := helper.AuditPublisher(ctx, broker, req); err != nil {
+		pkg.Logrus(cons.ERROR, "Audit entry %s of %s is not published: %v", req.Action, req.TargetType, err)
+	}
+}
@@ -6,6 +6,7 @@ require (
 	github.com/goccy/go-json v0.10.5
 	github.com/google/uuid v1.6.0
 	github.com/meilisearch/meilisearch-go v0.32.0
+	github.com/rabbitmq/amqp091-go v1.10.0
 	github.com/restuwahyu13/go-playground-converter v1.0.3
 	github.com/sirupsen/logrus v1.9.3
 )
@@ -22,7 +23,6 @@ require (
 	github.com/josharian/intern v1.0.0 // indirect
 	github.com/leodido/go-urn v1.4.0 // indirect
 	github.com/mailru/easyjson v0.9.0 // indirect
-	github.com/rabbitmq/amqp091-go v1.10.0 // indirect
 	github.com/robfig/cron/v3 v3.0.1 // indirect
 	golang.org/x/net v0.40.0 // indirect
 )
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	gpc "github.com/restuwahyu13/go-playground-converter"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+type apiKeysController struct {
+	usecase inf.IApiKeysUsecase
+}
+
+func NewApiKeysController(options dto.ControllerOptions[inf.IApiKeysUsecase]) inf.IApiKeysController {
+	return apiKeysController{usecase: options.USECASE}
+}
+
+func (c apiKeysController) CreateApiKey(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parser := helper.NewParser()
+
+	res := opt.Response{}
+	req := dto.Request[dto.CreateApiKeyDTO]{}
+
+	if err := parser.Decode(r.Body, &req.Body); err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	req.Body.CreatedBy, _ = ctx.Value(cons.AUTH_USER_ID).(string)
+
+	errors, err := gpc.Validator(req.Body)
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if errors != nil {
+		res.StatCode = http.StatusUnprocessableEntity
+		res.Errors = errors.Errors
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if res = c.usecase.CreateApiKey(ctx, req); res.StatCode >= http.StatusBadRequest {
+		if res.StatCode >= http.StatusInternalServerError {
+			pkg.Logrus(cons.ERROR, res.ErrMsg)
+			res.ErrMsg = cons.DEFAULT_ERR_MSG
+		}
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	helper.Api(rw, r, res)
+	return
+}
+
+func (c apiKeysController) FindAllApiKeys(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	transform := helper.NewTransform()
+
+	res := opt.Response{}
+	req := dto.Request[dto.ListApiKeysDTO]{}
+
+	if err := transform.QueryToStruct(r.URL.Query().Encode(), &req.Query); err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	errors, err := gpc.Validator(req.Query)
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if errors != nil {
+		res.StatCode = http.StatusUnprocessableEntity
+		res.Errors = errors.Errors
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if res = c.usecase.FindAllApiKeys(ctx, req); res.StatCode >= http.StatusBadRequest {
+		if res.StatCode >= http.StatusInternalServerError {
+			pkg.Logrus(cons.ERROR, res.ErrMsg)
+			res.ErrMsg = cons.DEFAULT_ERR_MSG
+		}
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	helper.Api(rw, r, res)
+	return
+}
+
+func (c apiKeysController) RotateApiKey(rw http.ResponseWriter, r *http.Request) {
+	c.apiKey(rw, r, c.usecase.RotateApiKey)
+}
+
+func (c apiKeysController) RevokeApiKey(rw http.ResponseWriter, r *http.Request) {
+	c.apiKey(rw, r, c.usecase.RevokeApiKey)
+}
+
+func (c apiKeysController) apiKey(rw http.ResponseWriter, r *http.Request, handler func(ctx context.Context, req dto.Request[dto.ApiKeyIDDTO]) opt.Response) {
+	ctx := r.Context()
+
+	res := opt.Response{}
+	req := dto.Request[dto.ApiKeyIDDTO]{}
+
+	req.Param.ID = chi.URLParam(r, "id")
+
+	errors, err := gpc.Validator(req.Param)
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if errors != nil {
+		res.StatCode = http.StatusUnprocessableEntity
+		res.Errors = errors.Errors
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if res = handler(ctx, req); res.StatCode >= http.StatusBadRequest {
+		if res.StatCode >= http.StatusInternalServerError {
+			pkg.Logrus(cons.ERROR, res.ErrMsg)
+			res.ErrMsg = cons.DEFAULT_ERR_MSG
+		}
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	helper.Api(rw, r, res)
+	return
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"net/http"
+
+	gpc "github.com/restuwahyu13/go-playground-converter"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+type auditLogsController struct {
+	usecase inf.IAuditLogsUsecase
+}
+
+func NewAuditLogsController(options dto.ControllerOptions[inf.IAuditLogsUsecase]) inf.IAuditLogsController {
+	return auditLogsController{usecase: options.USECASE}
+}
+
+func (c auditLogsController) FindAllAuditLogs(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	transform := helper.NewTransform()
+
+	res := opt.Response{}
+	req := dto.Request[dto.ListAuditLogsDTO]{}
+
+	if err := transform.QueryToStruct(r.URL.Query().Encode(), &req.Query); err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	errors, err := gpc.Validator(req.Query)
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if errors != nil {
+		res.StatCode = http.StatusUnprocessableEntity
+		res.Errors = errors.Errors
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if res = c.usecase.FindAllAuditLogs(ctx, req); res.StatCode >= http.StatusBadRequest {
+		if res.StatCode >= http.StatusInternalServerError {
+			pkg.Logrus(cons.ERROR, res.ErrMsg)
+			res.ErrMsg = cons.DEFAULT_ERR_MSG
+		}
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	helper.Api(rw, r, res)
+	return
+}
@@ -0,0 +1,176 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	gpc "github.com/restuwahyu13/go-playground-converter"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+type authController struct {
+	usecase inf.IAuthUsecase
+}
+
+func NewAuthController(options dto.ControllerOptions[inf.IAuthUsecase]) inf.IAuthController {
+	return authController{usecase: options.USECASE}
+}
+
+func (c authController) Login(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parser := helper.NewParser()
+
+	res := opt.Response{}
+	req := dto.Request[dto.LoginDTO]{}
+
+	if err := parser.Decode(r.Body, &req.Body); err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	errors, err := gpc.Validator(req.Body)
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if errors != nil {
+		res.StatCode = http.StatusUnprocessableEntity
+		res.Errors = errors.Errors
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if res = c.usecase.Login(ctx, req); res.StatCode >= http.StatusBadRequest {
+		if res.StatCode >= http.StatusInternalServerError {
+			pkg.Logrus(cons.ERROR, res.ErrMsg)
+			res.ErrMsg = cons.DEFAULT_ERR_MSG
+		}
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	helper.Api(rw, r, res)
+	return
+}
+
+func (c authController) Refresh(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parser := helper.NewParser()
+
+	res := opt.Response{}
+	req := dto.Request[dto.RefreshTokenDTO]{}
+
+	if err := parser.Decode(r.Body, &req.Body); err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	errors, err := gpc.Validator(req.Body)
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if errors != nil {
+		res.StatCode = http.StatusUnprocessableEntity
+		res.Errors = errors.Errors
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if res = c.usecase.Refresh(ctx, req); res.StatCode >= http.StatusBadRequest {
+		if res.StatCode >= http.StatusInternalServerError {
+			pkg.Logrus(cons.ERROR, res.ErrMsg)
+			res.ErrMsg = cons.DEFAULT_ERR_MSG
+		}
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	helper.Api(rw, r, res)
+	return
+}
+
+func (c authController) Logout(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	res := opt.Response{}
+	req := dto.Request[dto.LogoutDTO]{}
+
+	req.Body.UserID, _ = ctx.Value(cons.AUTH_USER_ID).(string)
+	req.Body.SessionID, _ = ctx.Value(cons.AUTH_SESSION_ID).(string)
+
+	errors, err := gpc.Validator(req.Body)
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if errors != nil {
+		res.StatCode = http.StatusUnauthorized
+		res.ErrMsg = "Authorization is required"
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if res = c.usecase.Logout(ctx, req); res.StatCode >= http.StatusBadRequest {
+		if res.StatCode >= http.StatusInternalServerError {
+			pkg.Logrus(cons.ERROR, res.ErrMsg)
+			res.ErrMsg = cons.DEFAULT_ERR_MSG
+		}
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	helper.Api(rw, r, res)
+	return
+}
+
+// Jwks write the key set without response envelope, so standard jwt library can consume it directly
+func (c authController) Jwks(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parser := helper.NewParser()
+
+	res := opt.Response{}
+
+	if res = c.usecase.Jwks(ctx); res.StatCode >= http.StatusBadRequest {
+		if res.StatCode >= http.StatusInternalServerError {
+			pkg.Logrus(cons.ERROR, res.ErrMsg)
+			res.ErrMsg = cons.DEFAULT_ERR_MSG
+		}
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", cons.KEYSET_JWKS_MAX_AGE))
+	rw.Header().Del("Expires")
+	rw.Header().Del("Pragma")
+	rw.Header().Del("X-Accel-Expires")
+	rw.WriteHeader(http.StatusOK)
+
+	if err := parser.Encode(rw, res.Data); err != nil {
+		pkg.Logrus(cons.ERROR, err)
+	}
+
+	return
+}
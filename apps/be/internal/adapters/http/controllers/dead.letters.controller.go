@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	gpc "github.com/restuwahyu13/go-playground-converter"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+type deadLettersController struct {
+	usecase inf.IDeadLettersUsecase
+}
+
+func NewDeadLettersController(options dto.ControllerOptions[inf.IDeadLettersUsecase]) inf.IDeadLettersController {
+	return deadLettersController{usecase: options.USECASE}
+}
+
+func (c deadLettersController) FindAllDeadLetters(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	transform := helper.NewTransform()
+
+	res := opt.Response{}
+	req := dto.Request[dto.ListDeadLettersDTO]{}
+
+	if err := transform.QueryToStruct(r.URL.Query().Encode(), &req.Query); err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	errors, err := gpc.Validator(req.Query)
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if errors != nil {
+		res.StatCode = http.StatusUnprocessableEntity
+		res.Errors = errors.Errors
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if res = c.usecase.FindAllDeadLetters(ctx, req); res.StatCode >= http.StatusBadRequest {
+		if res.StatCode >= http.StatusInternalServerError {
+			pkg.Logrus(cons.ERROR, res.ErrMsg)
+			res.ErrMsg = cons.DEFAULT_ERR_MSG
+		}
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	helper.Api(rw, r, res)
+	return
+}
+
+func (c deadLettersController) FindDeadLetter(rw http.ResponseWriter, r *http.Request) {
+	c.deadLetter(rw, r, c.usecase.FindDeadLetter)
+}
+
+func (c deadLettersController) ReplayDeadLetter(rw http.ResponseWriter, r *http.Request) {
+	c.deadLetter(rw, r, c.usecase.ReplayDeadLetter)
+}
+
+func (c deadLettersController) ReplayAllDeadLetters(rw http.ResponseWriter, r *http.Request) {
+	c.deadLetters(rw, r, c.usecase.ReplayAllDeadLetters)
+}
+
+func (c deadLettersController) DiscardDeadLetter(rw http.ResponseWriter, r *http.Request) {
+	c.deadLetter(rw, r, c.usecase.DiscardDeadLetter)
+}
+
+func (c deadLettersController) DiscardAllDeadLetters(rw http.ResponseWriter, r *http.Request) {
+	c.deadLetters(rw, r, c.usecase.DiscardAllDeadLetters)
+}
+
+func (c deadLettersController) deadLetter(rw http.ResponseWriter, r *http.Request, handler func(ctx context.Context, req dto.Request[dto.DeadLetterIDDTO]) opt.Response) {
+	ctx := r.Context()
+
+	res := opt.Response{}
+	req := dto.Request[dto.DeadLetterIDDTO]{}
+
+	req.Param.ID = chi.URLParam(r, "id")
+
+	errors, err := gpc.Validator(req.Param)
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if errors != nil {
+		res.StatCode = http.StatusUnprocessableEntity
+		res.Errors = errors.Errors
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if res = handler(ctx, req); res.StatCode >= http.StatusBadRequest {
+		if res.StatCode >= http.StatusInternalServerError {
+			pkg.Logrus(cons.ERROR, res.ErrMsg)
+			res.ErrMsg = cons.DEFAULT_ERR_MSG
+		}
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	helper.Api(rw, r, res)
+	return
+}
+
+func (c deadLettersController) deadLetters(rw http.ResponseWriter, r *http.Request, handler func(ctx context.Context, req dto.Request[dto.DeadLettersFilterDTO]) opt.Response) {
+	ctx := r.Context()
+	transform := helper.NewTransform()
+
+	res := opt.Response{}
+	req := dto.Request[dto.DeadLettersFilterDTO]{}
+
+	if err := transform.QueryToStruct(r.URL.Query().Encode(), &req.Query); err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	errors, err := gpc.Validator(req.Query)
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if errors != nil {
+		res.StatCode = http.StatusUnprocessableEntity
+		res.Errors = errors.Errors
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if res = handler(ctx, req); res.StatCode >= http.StatusBadRequest {
+		if res.StatCode >= http.StatusInternalServerError {
+			pkg.Logrus(cons.ERROR, res.ErrMsg)
+			res.ErrMsg = cons.DEFAULT_ERR_MSG
+		}
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	helper.Api(rw, r, res)
+	return
+}
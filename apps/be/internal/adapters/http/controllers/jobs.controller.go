@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	gpc "github.com/restuwahyu13/go-playground-converter"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+type jobsController struct {
+	usecase inf.IJobsUsecase
+}
+
+func NewJobsController(options dto.ControllerOptions[inf.IJobsUsecase]) inf.IJobsController {
+	return jobsController{usecase: options.USECASE}
+}
+
+func (c jobsController) FindAllJobs(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	res := opt.Response{}
+
+	if res = c.usecase.FindAllJobs(ctx); res.StatCode >= http.StatusBadRequest {
+		if res.StatCode >= http.StatusInternalServerError {
+			pkg.Logrus(cons.ERROR, res.ErrMsg)
+			res.ErrMsg = cons.DEFAULT_ERR_MSG
+		}
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	helper.Api(rw, r, res)
+	return
+}
+
+func (c jobsController) FindAllJobRuns(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	res := opt.Response{}
+
+	if res = c.usecase.FindAllJobRuns(ctx); res.StatCode >= http.StatusBadRequest {
+		if res.StatCode >= http.StatusInternalServerError {
+			pkg.Logrus(cons.ERROR, res.ErrMsg)
+			res.ErrMsg = cons.DEFAULT_ERR_MSG
+		}
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	helper.Api(rw, r, res)
+	return
+}
+
+func (c jobsController) TriggerJob(rw http.ResponseWriter, r *http.Request) {
+	c.jobControl(rw, r, c.usecase.TriggerJob)
+}
+
+func (c jobsController) PauseJob(rw http.ResponseWriter, r *http.Request) {
+	c.jobControl(rw, r, c.usecase.PauseJob)
+}
+
+func (c jobsController) ResumeJob(rw http.ResponseWriter, r *http.Request) {
+	c.jobControl(rw, r, c.usecase.ResumeJob)
+}
+
+func (c jobsController) jobControl(rw http.ResponseWriter, r *http.Request, handler func(ctx context.Context, req dto.Request[dto.JobNameDTO]) opt.Response) {
+	ctx := r.Context()
+
+	res := opt.Response{}
+	req := dto.Request[dto.JobNameDTO]{}
+
+	req.Param.Name = chi.URLParam(r, "name")
+
+	errors, err := gpc.Validator(req.Param)
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if errors != nil {
+		res.StatCode = http.StatusUnprocessableEntity
+		res.Errors = errors.Errors
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if res = handler(ctx, req); res.StatCode >= http.StatusBadRequest {
+		if res.StatCode >= http.StatusInternalServerError {
+			pkg.Logrus(cons.ERROR, res.ErrMsg)
+			res.ErrMsg = cons.DEFAULT_ERR_MSG
+		}
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	helper.Api(rw, r, res)
+	return
+}
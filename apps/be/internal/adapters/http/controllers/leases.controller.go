@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	gpc "github.com/restuwahyu13/go-playground-converter"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+type leasesController struct {
+	usecase inf.ILeasesUsecase
+}
+
+func NewLeasesController(options dto.ControllerOptions[inf.ILeasesUsecase]) inf.ILeasesController {
+	return leasesController{usecase: options.USECASE}
+}
+
+func (c leasesController) FindAllLeases(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	res := opt.Response{}
+
+	if res = c.usecase.FindAllLeases(ctx); res.StatCode >= http.StatusBadRequest {
+		if res.StatCode >= http.StatusInternalServerError {
+			pkg.Logrus(cons.ERROR, res.ErrMsg)
+			res.ErrMsg = cons.DEFAULT_ERR_MSG
+		}
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	helper.Api(rw, r, res)
+	return
+}
+
+func (c leasesController) FindLease(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	res := opt.Response{}
+	req := dto.Request[dto.FindLeaseDTO]{}
+
+	req.Param.Name = chi.URLParam(r, "name")
+
+	errors, err := gpc.Validator(req.Param)
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if errors != nil {
+		res.StatCode = http.StatusUnprocessableEntity
+		res.Errors = errors.Errors
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	if res = c.usecase.FindLease(ctx, req); res.StatCode >= http.StatusBadRequest {
+		if res.StatCode >= http.StatusInternalServerError {
+			pkg.Logrus(cons.ERROR, res.ErrMsg)
+			res.ErrMsg = cons.DEFAULT_ERR_MSG
+		}
+
+		helper.Api(rw, r, res)
+		return
+	}
+
+	helper.Api(rw, r, res)
+	return
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/uptrace/bun"
+
+	service "github.com/restuwahyu13/go-fast-search/domain/services"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+// ApiKey authenticate service caller with X-API-Key header granting key scopes as permissions, without the header auth middleware take over
+func ApiKey(db *bun.DB) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			key := r.Header.Get(cons.API_KEY_HEADER)
+			if key == "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			req := dto.Request[dto.AuthenticateApiKeyDTO]{}
+			req.Body.Key = key
+
+			res := service.NewApiKeysService(dto.ServiceOptions{DB: db}).AuthenticateApiKey(ctx, req)
+			if res.StatCode >= http.StatusBadRequest {
+				if res.StatCode >= http.StatusInternalServerError {
+					pkg.Logrus(cons.ERROR, res.ErrMsg)
+					res.ErrMsg = cons.DEFAULT_ERR_MSG
+				}
+
+				helper.Api(w, r, res)
+				return
+			}
+
+			principal, ok := res.Data.(opt.ApiKeyPrincipal)
+			if !ok {
+				helper.Api(w, r, opt.Response{StatCode: http.StatusUnauthorized, ErrMsg: "Invalid api key"})
+				return
+			}
+
+			sharingCtx := context.WithValue(ctx, cons.AUTH_API_KEY_ID, principal.ID)
+			sharingCtx = context.WithValue(sharingCtx, cons.AUTH_USER_ID, principal.Owner)
+			sharingCtx = context.WithValue(sharingCtx, cons.AUTH_PERMISSIONS, principal.Scopes)
+
+			h.ServeHTTP(w, r.WithContext(sharingCtx))
+
+			return
+		})
+	}
+}
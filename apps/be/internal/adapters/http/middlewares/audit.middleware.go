@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+)
+
+// Audit share client ip with the service, so the audit entry published by the service know where the request come from
+func Audit(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), cons.AUDIT_IP_ADDRESS, helper.IPAddress(r))))
+	})
+}
@@ -2,97 +2,64 @@ package middleware
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
-	"math"
 	"net/http"
-
 	"strings"
 
-	"github.com/lestrrat-go/jwx/v3/jwt"
 	"github.com/redis/go-redis/v9"
 	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
 	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
 	opt "github.com/restuwahyu13/go-fast-search/shared/output"
 	"github.com/restuwahyu13/go-fast-search/shared/pkg"
 )
 
-func Auth(expired int, con *redis.Client) func(http.Handler) http.Handler {
+/**
+* Auth verify bearer token by AUTH_MODE, local accept token issued by jwt sign, oidc accept token issued by external
+* identity provider and both pick the verifier from iss claim, publics is list of "METHOD /path" pattern skipping verification
+ */
+func Auth(env *dto.Environtment, con *redis.Client, publics ...string) func(http.Handler) http.Handler {
 	return func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
 			res := opt.Response{}
 
-			jose := pkg.NewJose(ctx)
-			crypto := helper.NewCrypto()
-
-			headers := r.Header.Get("Authorization")
-			if !strings.Contains(headers, "Bearer") {
-				res.StatCode = http.StatusUnauthorized
-				res.ErrMsg = "Authorization is required"
-
-				helper.Api(w, r, res)
+			if apiKeyID, _ := ctx.Value(cons.AUTH_API_KEY_ID).(string); apiKeyID != "" {
+				h.ServeHTTP(w, r)
 				return
 			}
 
-			token := strings.Split(headers, "Bearer ")[1]
-
-			if len(strings.Split(token, ".")) != 3 {
-				res.StatCode = http.StatusUnauthorized
-				res.ErrMsg = "Invalid token format"
-
-				helper.Api(w, r, res)
-				return
+			for _, public := range publics {
+				if helper.MatchRoute(public, fmt.Sprintf("%s %s", r.Method, r.URL.Path)) {
+					h.ServeHTTP(w, r.WithContext(context.WithValue(ctx, cons.AUTH_PUBLIC, true)))
+					return
+				}
 			}
 
-			tokenMetadata, err := jwt.ParseRequest(r, jwt.WithHeaderKey("Authorization"), jwt.WithVerify(false))
-			if err != nil {
-				pkg.Logrus(cons.ERROR, err)
-				res.StatCode = http.StatusUnauthorized
-				res.ErrMsg = "Invalid access token"
-
-				helper.Api(w, r, res)
-				return
-			}
-
-			aud, ok := tokenMetadata.Audience()
-			if !ok {
-				res.StatCode = http.StatusUnauthorized
-				res.ErrMsg = "Invalid access token"
-
-				helper.Api(w, r, res)
-				return
-			}
-
-			iss, ok := tokenMetadata.Issuer()
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
 			if !ok {
 				res.StatCode = http.StatusUnauthorized
-				res.ErrMsg = "Invalid access token"
+				res.ErrMsg = "Authorization is required"
 
 				helper.Api(w, r, res)
 				return
 			}
 
-			sub, ok := tokenMetadata.Subject()
-			if !ok {
+			if len(strings.Split(token, ".")) != 3 {
 				res.StatCode = http.StatusUnauthorized
-				res.ErrMsg = "Invalid access token"
+				res.ErrMsg = "Invalid token format"
 
 				helper.Api(w, r, res)
 				return
 			}
 
-			jti, ok := tokenMetadata.JwtID()
-			if !ok {
-				res.StatCode = http.StatusUnauthorized
-				res.ErrMsg = "Invalid access token"
-
-				helper.Api(w, r, res)
-				return
+			verify := verifyLocal
+			if mode := env.AUTH.MODE; mode == cons.AUTH_MODE_OIDC || (mode == cons.AUTH_MODE_BOTH && pkg.NewOidc(ctx, env).Issued(token)) {
+				verify = verifyOidc
 			}
 
-			timestamp := ""
-			if err := tokenMetadata.Get("timestamp", &timestamp); err != nil {
+			sharingCtx, err := verify(ctx, env, con, token)
+			if err != nil {
 				pkg.Logrus(cons.ERROR, err)
 				res.StatCode = http.StatusUnauthorized
 				res.ErrMsg = "Invalid access token"
@@ -101,43 +68,54 @@ func Auth(expired int, con *redis.Client) func(http.Handler) http.Handler {
 				return
 			}
 
-			suffix := int(math.Pow(float64(expired), float64(len(aud[0])+len(iss)+len(sub))))
-			secretKey := fmt.Sprintf("%s:%s:%s:%s:%d", aud[0], iss, sub, timestamp, suffix)
-			secretData := hex.EncodeToString([]byte(secretKey))
-
-			key, err := crypto.AES256Decrypt(secretData, jti)
-			if err != nil {
-				pkg.Logrus(cons.ERROR, err)
-				res.StatCode = http.StatusUnauthorized
-				res.ErrMsg = "Invalid access token"
+			h.ServeHTTP(w, r.WithContext(sharingCtx))
 
-				helper.Api(w, r, res)
-				return
-			}
+			return
+		})
+	}
+}
 
-			rds, err := pkg.NewRedis(ctx, con)
-			if err != nil {
-				pkg.Logrus(cons.ERROR, err)
-				res.StatCode = http.StatusUnauthorized
-				res.ErrMsg = "Invalid access token"
+func verifyLocal(ctx context.Context, env *dto.Environtment, con *redis.Client, token string) (context.Context, error) {
+	verifyMetadata, err := pkg.NewJsonWebToken(ctx, env, con).Verify(token)
+	if err != nil {
+		return nil, err
+	}
 
-				helper.Api(w, r, res)
-				return
-			}
+	subject, session, ok := strings.Cut(verifyMetadata.Prefix, ":")
+	if !ok || subject == "" || session == "" || strings.Contains(session, ":") {
+		return nil, cons.AUTH_TOKEN_INVALID
+	}
 
-			if _, err = jose.JwtVerify(key, token, rds); err != nil {
-				pkg.Logrus(cons.ERROR, err)
-				res.StatCode = http.StatusUnauthorized
-				res.ErrMsg = "Invalid access token"
+	role, _ := verifyMetadata.Claims[cons.AUTH_ROLE].(string)
+	permissions := []string{}
 
-				helper.Api(w, r, res)
-				return
+	if values, ok := verifyMetadata.Claims[cons.AUTH_PERMISSIONS].([]any); ok {
+		for _, value := range values {
+			if permission, ok := value.(string); ok {
+				permissions = append(permissions, permission)
 			}
+		}
+	}
 
-			sharingCtx := context.WithValue(r.Context(), "user_id", key)
-			h.ServeHTTP(w, r.WithContext(sharingCtx))
+	sharingCtx := context.WithValue(ctx, cons.AUTH_USER_ID, subject)
+	sharingCtx = context.WithValue(sharingCtx, cons.AUTH_SESSION_ID, session)
+	sharingCtx = context.WithValue(sharingCtx, cons.AUTH_ROLE, role)
+	sharingCtx = context.WithValue(sharingCtx, cons.AUTH_PERMISSIONS, permissions)
 
-			return
-		})
+	return sharingCtx, nil
+}
+
+// verifyOidc verify token of external identity provider, it has no session so logout is not available for it
+func verifyOidc(ctx context.Context, env *dto.Environtment, _ *redis.Client, token string) (context.Context, error) {
+	oidcMetadata, err := pkg.NewOidc(ctx, env).Verify(token)
+	if err != nil {
+		return nil, err
 	}
+
+	sharingCtx := context.WithValue(ctx, cons.AUTH_USER_ID, oidcMetadata.Subject)
+	sharingCtx = context.WithValue(sharingCtx, cons.AUTH_ROLE, oidcMetadata.Role)
+	sharingCtx = context.WithValue(sharingCtx, cons.AUTH_PERMISSIONS, oidcMetadata.Permissions)
+	sharingCtx = context.WithValue(sharingCtx, cons.AUTH_TENANT_ID, oidcMetadata.TenantID)
+
+	return sharingCtx, nil
 }
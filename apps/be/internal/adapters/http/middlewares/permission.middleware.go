@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+// Permission require every permissions granted to the token verified by auth middleware, public route is denied
+func Permission(permissions ...string) func(http.Handler) http.Handler {
+	return permission(false, permissions...)
+}
+
+// PermissionOrPublic is Permission that let a route matched by AUTH_PUBLIC_ROUTES through without a token
+func PermissionOrPublic(permissions ...string) func(http.Handler) http.Handler {
+	return permission(true, permissions...)
+}
+
+func permission(allowPublic bool, permissions ...string) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			res := opt.Response{}
+
+			if public, _ := ctx.Value(cons.AUTH_PUBLIC).(bool); public && allowPublic {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			granted, _ := ctx.Value(cons.AUTH_PERMISSIONS).([]string)
+
+			for _, permission := range permissions {
+				if !slices.Contains(granted, permission) {
+					res.StatCode = http.StatusForbidden
+					res.ErrMsg = "Permission denied"
+
+					helper.Api(w, r, res)
+					return
+				}
+			}
+
+			h.ServeHTTP(w, r)
+			return
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+/**
+* RateLimit apply the first RATE_LIMIT_RULES rule matching the route and identity, identity is the api key or subject
+* verified by auth middleware and client ip otherwise, so it must be placed after auth, request is allowed when redis fail
+ */
+func RateLimit(env *dto.Environtment, con *redis.Client) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			res := opt.Response{}
+
+			kind, identity := rateLimitIdentity(r)
+			route := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+
+			for _, rule := range env.RATE_LIMIT.RULES {
+				if !helper.MatchRoute(rule.ROUTE, route) || (rule.IDENTITY != "" && rule.IDENTITY != kind) {
+					continue
+				}
+
+				key := fmt.Sprintf(cons.RATE_LIMIT_KEY, rule.ROUTE, kind, identity)
+				window := time.Duration(time.Second * time.Duration(rule.WINDOW))
+
+				rateLimitMetadata, err := pkg.NewLimiter(ctx, con).Allow(key, rule.LIMIT, window)
+				if err != nil {
+					pkg.Logrus(cons.ERROR, err)
+					break
+				}
+
+				w.Header().Set("RateLimit-Limit", strconv.Itoa(rateLimitMetadata.Limit))
+				w.Header().Set("RateLimit-Remaining", strconv.Itoa(rateLimitMetadata.Remaining))
+				w.Header().Set("RateLimit-Reset", strconv.Itoa(rateLimitMetadata.Reset))
+				w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", rule.LIMIT, rule.WINDOW))
+
+				if !rateLimitMetadata.Allowed {
+					w.Header().Set("Retry-After", strconv.Itoa(rateLimitMetadata.RetryAfter))
+
+					res.StatCode = http.StatusTooManyRequests
+					res.ErrMsg = fmt.Sprintf("Too many requests, retry after %d seconds", rateLimitMetadata.RetryAfter)
+
+					helper.Api(w, r, res)
+					return
+				}
+
+				break
+			}
+
+			h.ServeHTTP(w, r)
+			return
+		})
+	}
+}
+
+func rateLimitIdentity(r *http.Request) (string, string) {
+	ctx := r.Context()
+
+	if apiKeyID, _ := ctx.Value(cons.AUTH_API_KEY_ID).(string); apiKeyID != "" {
+		return cons.RATE_LIMIT_IDENTITY_KEY, apiKeyID
+	}
+
+	if public, _ := ctx.Value(cons.AUTH_PUBLIC).(bool); !public {
+		if userID, _ := ctx.Value(cons.AUTH_USER_ID).(string); userID != "" {
+			return cons.RATE_LIMIT_IDENTITY_USER, userID
+		}
+	}
+
+	return cons.RATE_LIMIT_IDENTITY_IP, helper.IPAddress(r)
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+)
+
+// RealIP replace the remote address with the client ip forwarded by TRUSTED_PROXIES, forwarded header of other client is ignored
+func RealIP(env *dto.Environtment) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.RemoteAddr = helper.ClientIP(r, env.APP.TRUSTED_PROXIES)
+			h.ServeHTTP(w, r)
+		})
+	}
+}
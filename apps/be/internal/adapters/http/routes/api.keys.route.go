@@ -0,0 +1,29 @@
+package route
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	middleware "github.com/restuwahyu13/go-fast-search/internal/adapters/http/middlewares"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type apiKeysRoute struct {
+	router     chi.Router
+	controller inf.IApiKeysController
+}
+
+func NewApiKeysRoute(options dto.RouteOptions[inf.IApiKeysController]) {
+	route := apiKeysRoute{router: options.ROUTER, controller: options.CONTROLLER}
+	auth := middleware.Auth(&options.ENV.Config, options.RDS)
+	limit := middleware.RateLimit(&options.ENV.Config, options.RDS)
+
+	route.router.With(auth, limit, middleware.Permission(cons.PERMISSION_KEYS_ADMIN)).Route(helper.Version("api-keys"), func(r chi.Router) {
+		r.Post("/", route.controller.CreateApiKey)
+		r.Get("/", route.controller.FindAllApiKeys)
+		r.Post("/{id}/rotate", route.controller.RotateApiKey)
+		r.Post("/{id}/revoke", route.controller.RevokeApiKey)
+	})
+}
@@ -0,0 +1,26 @@
+package route
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	middleware "github.com/restuwahyu13/go-fast-search/internal/adapters/http/middlewares"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type auditLogsRoute struct {
+	router     chi.Router
+	controller inf.IAuditLogsController
+}
+
+func NewAuditLogsRoute(options dto.RouteOptions[inf.IAuditLogsController]) {
+	route := auditLogsRoute{router: options.ROUTER, controller: options.CONTROLLER}
+	auth := middleware.Auth(&options.ENV.Config, options.RDS)
+	limit := middleware.RateLimit(&options.ENV.Config, options.RDS)
+
+	route.router.With(auth, limit, middleware.Permission(cons.PERMISSION_AUDIT_READ)).Route(helper.Version("audit-logs"), func(r chi.Router) {
+		r.Get("/", route.controller.FindAllAuditLogs)
+	})
+}
@@ -0,0 +1,30 @@
+package route
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	middleware "github.com/restuwahyu13/go-fast-search/internal/adapters/http/middlewares"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type authRoute struct {
+	router     chi.Router
+	controller inf.IAuthController
+}
+
+func NewAuthRoute(options dto.RouteOptions[inf.IAuthController]) {
+	route := authRoute{router: options.ROUTER, controller: options.CONTROLLER}
+	auth := middleware.Auth(&options.ENV.Config, options.RDS)
+	limit := middleware.RateLimit(&options.ENV.Config, options.RDS)
+
+	route.router.With(limit).Route(helper.Version("auth"), func(r chi.Router) {
+		r.Post("/login", route.controller.Login)
+		r.Post("/refresh", route.controller.Refresh)
+		r.With(auth).Post("/logout", route.controller.Logout)
+	})
+
+	route.router.Get(cons.KEYSET_JWKS_PATH, route.controller.Jwks)
+}
@@ -0,0 +1,30 @@
+package route
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	middleware "github.com/restuwahyu13/go-fast-search/internal/adapters/http/middlewares"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type deadLettersRoute struct {
+	router     chi.Router
+	controller inf.IDeadLettersController
+}
+
+func NewDeadLettersRoute(options dto.RouteOptions[inf.IDeadLettersController]) {
+	route := deadLettersRoute{router: options.ROUTER, controller: options.CONTROLLER}
+	auth := middleware.Auth(&options.ENV.Config, options.RDS)
+
+	route.router.With(auth, middleware.Permission(cons.PERMISSION_SEARCH_ADMIN)).Route(helper.Version("dead-letters"), func(r chi.Router) {
+		r.Get("/", route.controller.FindAllDeadLetters)
+		r.Post("/replay", route.controller.ReplayAllDeadLetters)
+		r.Post("/discard", route.controller.DiscardAllDeadLetters)
+		r.Get("/{id}", route.controller.FindDeadLetter)
+		r.Post("/{id}/replay", route.controller.ReplayDeadLetter)
+		r.Post("/{id}/discard", route.controller.DiscardDeadLetter)
+	})
+}
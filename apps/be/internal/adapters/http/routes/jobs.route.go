@@ -0,0 +1,30 @@
+package route
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	middleware "github.com/restuwahyu13/go-fast-search/internal/adapters/http/middlewares"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type jobsRoute struct {
+	router     chi.Router
+	controller inf.IJobsController
+}
+
+func NewJobsRoute(options dto.RouteOptions[inf.IJobsController]) {
+	route := jobsRoute{router: options.ROUTER, controller: options.CONTROLLER}
+
+	auth := middleware.Auth(&options.ENV.Config, options.RDS)
+
+	route.router.With(auth, middleware.Permission(cons.PERMISSION_SEARCH_ADMIN)).Route(helper.Version("jobs"), func(r chi.Router) {
+		r.Get("/", route.controller.FindAllJobs)
+		r.Get("/runs", route.controller.FindAllJobRuns)
+		r.Post("/{name}/trigger", route.controller.TriggerJob)
+		r.Post("/{name}/pause", route.controller.PauseJob)
+		r.Post("/{name}/resume", route.controller.ResumeJob)
+	})
+}
@@ -0,0 +1,26 @@
+package route
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	middleware "github.com/restuwahyu13/go-fast-search/internal/adapters/http/middlewares"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type leasesRoute struct {
+	router     chi.Router
+	controller inf.ILeasesController
+}
+
+func NewLeasesRoute(options dto.RouteOptions[inf.ILeasesController]) {
+	route := leasesRoute{router: options.ROUTER, controller: options.CONTROLLER}
+	auth := middleware.Auth(&options.ENV.Config, options.RDS)
+
+	route.router.With(auth, middleware.Permission(cons.PERMISSION_SEARCH_ADMIN)).Route(helper.Version("leases"), func(r chi.Router) {
+		r.Get("/", route.controller.FindAllLeases)
+		r.Get("/{name}", route.controller.FindLease)
+	})
+}
@@ -3,6 +3,8 @@ package route
 import (
 	"github.com/go-chi/chi/v5"
 
+	middleware "github.com/restuwahyu13/go-fast-search/internal/adapters/http/middlewares"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
 	"github.com/restuwahyu13/go-fast-search/shared/dto"
 	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
 	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
@@ -15,10 +17,12 @@ type usersRoute struct {
 
 func NewUsersRoute(options dto.RouteOptions[inf.IUsersController]) {
 	route := usersRoute{router: options.ROUTER, controller: options.CONTROLLER}
+	auth := middleware.Auth(&options.ENV.Config, options.RDS, options.ENV.Config.AUTH.PUBLIC_ROUTES...)
+	limit := middleware.RateLimit(&options.ENV.Config, options.RDS)
 
-	route.router.Route(helper.Version("users"), func(r chi.Router) {
-		r.Post("/", route.controller.CreateUsers)
-		r.Get("/", route.controller.FindAllUsers)
-		r.Put("/{id}", route.controller.UpdateUsers)
+	route.router.With(middleware.Audit, middleware.ApiKey(options.DB), auth, limit).Route(helper.Version("users"), func(r chi.Router) {
+		r.With(middleware.PermissionOrPublic(cons.PERMISSION_USERS_WRITE)).Post("/", route.controller.CreateUsers)
+		r.With(middleware.PermissionOrPublic(cons.PERMISSION_USERS_READ)).Get("/", route.controller.FindAllUsers)
+		r.With(middleware.PermissionOrPublic(cons.PERMISSION_USERS_WRITE)).Put("/{id}", route.controller.UpdateUsers)
 	})
 }
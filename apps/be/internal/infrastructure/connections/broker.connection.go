@@ -0,0 +1,26 @@
+package con
+
+import (
+	"github.com/wagslane/go-rabbitmq"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+)
+
+/**
+* Only rabbitmq driver need its own connection, redis driver reuse the redis connection
+* and memory driver need nothing, nil connection is returned for both
+ */
+func BrokerConnection(req dto.Request[dto.Environtment]) (*rabbitmq.Conn, error) {
+	switch req.Config.BROKER.DRIVER {
+
+	case "", cons.BROKER_DRIVER_RABBITMQ:
+		return RabbitConnection(req)
+
+	case cons.BROKER_DRIVER_REDIS, cons.BROKER_DRIVER_MEMORY:
+		return nil, nil
+
+	default:
+		return nil, cons.BROKER_UNKNOWN_DRIVER
+	}
+}
@@ -20,6 +20,6 @@ func RabbitConnection(req dto.Request[dto.Environtment]) (*rabbitmq.Conn, error)
 			Vhost:           req.Config.RABBITMQ.VSN,
 			FrameSize:       http.DefaultMaxHeaderBytes * 5,
 			Heartbeat:       interval,
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: req.Config.RABBITMQ.TLS_SKIP_VERIFY},
 		}))
 }
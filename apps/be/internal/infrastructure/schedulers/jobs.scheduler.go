@@ -0,0 +1,32 @@
+package scheduler
+
+import (
+	"time"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+)
+
+/**
+* Register new scheduler job here, crontab can be override with SCHEDULER_CRONTAB config
+ */
+func NewJobs(options dto.SchedulerOptions) []dto.Job {
+	return []dto.Job{
+		{
+			Name:    cons.JOB_NAME_SEARCH,
+			Crontab: cons.Every30Seconds,
+			Timeout: time.Duration(time.Minute * 5),
+			Overlap: cons.OVERLAP_SKIP,
+			Locks:   []string{cons.LEASE_SEARCH_SCHEDULER},
+			Handler: NewSearchScheduler(options).SearchHandler,
+		},
+		{
+			Name:    cons.JOB_NAME_SIGNING_KEY,
+			Crontab: cons.Every10Minutes,
+			Timeout: time.Duration(time.Minute * 1),
+			Overlap: cons.OVERLAP_SKIP,
+			Locks:   []string{cons.LEASE_SIGNING_KEY_SCHEDULER},
+			Handler: NewKeysetScheduler(options).RotateHandler,
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package scheduler
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+type keysetScheduler struct {
+	env dto.Request[dto.Environtment]
+	rds *redis.Client
+}
+
+func NewKeysetScheduler(options dto.SchedulerOptions) inf.IKeysetScheduler {
+	return keysetScheduler{env: options.ENV, rds: options.RDS}
+}
+
+/**
+* RotateHandler rotate the jwt signing key once the active key is older than JWT_KEY_ROTATION,
+* the job only check the age so triggering it manually does not force a rotation
+ */
+func (s keysetScheduler) RotateHandler(ctx context.Context, fence func() (int64, error)) error {
+	if _, err := fence(); err != nil {
+		return err
+	}
+
+	signingKey, err := pkg.NewKeyset(ctx, &s.env.Config, s.rds).Rotate()
+	if err != nil {
+		return err
+	}
+
+	if signingKey != nil {
+		pkg.Logrus(cons.INFO, "Signing key is rotated, active kid %s", signingKey.Kid)
+	}
+
+	return nil
+}
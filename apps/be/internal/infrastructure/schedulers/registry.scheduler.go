@@ -0,0 +1,565 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/guregu/null/v6/zero"
+	"github.com/lithammer/shortuuid"
+	"github.com/meilisearch/meilisearch-go"
+	"github.com/redis/go-redis/v9"
+	"github.com/uptrace/bun"
+
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	repo "github.com/restuwahyu13/go-fast-search/domain/repositories"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+type (
+	jobRegistry struct {
+		ctx      context.Context
+		env      dto.Request[dto.Environtment]
+		db       *bun.DB
+		rds      *redis.Client
+		mls      meilisearch.ServiceManager
+		cron     inf.ICron
+		holder   string
+		mutex    *sync.RWMutex
+		jobs     map[string]*jobEntry
+		leases   map[string]inf.ILease
+		runMutex *sync.RWMutex
+		runs     map[string]opt.JobRunMetadata
+		stopping *atomic.Bool
+		leaseCtx context.Context
+		release  context.CancelFunc
+		released *sync.WaitGroup
+	}
+
+	jobEntry struct {
+		job       dto.Job
+		scheduler gocron.Scheduler
+		cronJob   gocron.Job
+		running   *sync.Mutex
+		lastRunAt atomic.Int64
+		skipped   atomic.Int64
+		skippedAt atomic.Int64
+		skips     atomic.Int64
+	}
+)
+
+func NewJobRegistry(options dto.SchedulerOptions) inf.IJobRegistry {
+	hostname, _ := os.Hostname()
+	leaseCtx, release := context.WithCancel(options.CTX)
+
+	return jobRegistry{
+		ctx:      options.CTX,
+		env:      options.ENV,
+		db:       options.DB,
+		rds:      options.RDS,
+		mls:      options.MLS,
+		cron:     pkg.NewCron(),
+		holder:   fmt.Sprintf("%s:%d:%s", hostname, os.Getpid(), shortuuid.New()),
+		mutex:    new(sync.RWMutex),
+		jobs:     make(map[string]*jobEntry),
+		leases:   make(map[string]inf.ILease),
+		runMutex: new(sync.RWMutex),
+		runs:     make(map[string]opt.JobRunMetadata),
+		stopping: new(atomic.Bool),
+		leaseCtx: leaseCtx,
+		release:  release,
+		released: new(sync.WaitGroup),
+	}
+}
+
+func (r jobRegistry) Register(job dto.Job) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.jobs[job.Name]; ok {
+		return fmt.Errorf("job %s already registered", job.Name)
+	}
+
+	if crontab, ok := r.env.Config.SCHEDULER.CRONTAB[job.Name]; ok && crontab != "" {
+		job.Crontab = crontab
+	}
+
+	if job.Overlap == "" {
+		job.Overlap = cons.OVERLAP_SKIP
+	}
+
+	ttl := time.Duration(time.Second * time.Duration(r.env.Config.SCHEDULER.LEASE_TTL))
+	for _, lock := range job.Locks {
+		if _, ok := r.leases[lock]; !ok {
+			r.leases[lock] = pkg.NewLease(r.ctx, r.rds, dto.LeaseOptions{Name: lock, Holder: r.holder, TTL: ttl})
+		}
+	}
+
+	entry := &jobEntry{job: job, running: new(sync.Mutex)}
+
+	sch, cronJob, err := r.cron.Handler(job.Name, job.Crontab, func() {
+		r.execute(entry, cons.JOB_TRIGGER_SCHEDULE)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	entry.scheduler = sch
+	entry.cronJob = cronJob
+	r.jobs[job.Name] = entry
+
+	pkg.Logrus(cons.INFO, "Job %s is registered and execute at %s", job.Name, job.Crontab)
+	return nil
+}
+
+func (r jobRegistry) Start() {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, lease := range r.leases {
+		r.released.Add(1)
+
+		go func() {
+			defer r.released.Done()
+			lease.Run(r.leaseCtx)
+		}()
+	}
+
+	for _, entry := range r.jobs {
+		entry.scheduler.Start()
+	}
+}
+
+func (r jobRegistry) Jobs() []opt.JobMetadata {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	jobsMetadata := []opt.JobMetadata{}
+	running := r.running()
+
+	paused, err := r.paused()
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+	}
+
+	for _, entry := range r.jobs {
+		jobMetadata := opt.JobMetadata{}
+		jobMetadata.Name = entry.job.Name
+		jobMetadata.Kind = cons.JOB_KIND_SCHEDULER
+		jobMetadata.Crontab = entry.job.Crontab
+		jobMetadata.Overlap = entry.job.Overlap
+		jobMetadata.Locks = entry.job.Locks
+		jobMetadata.IsLeader = r.isLeader(entry.job)
+		jobMetadata.IsPaused = paused[entry.job.Name]
+		jobMetadata.Running = running[entry.job.Name]
+		jobMetadata.Skipped = entry.skips.Load()
+
+		if entry.job.Timeout > 0 {
+			jobMetadata.Timeout = entry.job.Timeout.String()
+		}
+
+		if nextRunAt, err := entry.cronJob.NextRun(); err == nil && !nextRunAt.IsZero() && !jobMetadata.IsPaused {
+			jobMetadata.NextRunAt = nextRunAt.Format(time.RFC3339)
+		}
+
+		if lastRunAt := entry.lastRunAt.Load(); lastRunAt > 0 {
+			jobMetadata.LastRunAt = time.Unix(0, lastRunAt).Format(time.RFC3339)
+		}
+
+		jobsMetadata = append(jobsMetadata, jobMetadata)
+	}
+
+	sort.Slice(jobsMetadata, func(i, j int) bool {
+		return jobsMetadata[i].Name < jobsMetadata[j].Name
+	})
+
+	return jobsMetadata
+}
+
+func (r jobRegistry) Runs() []opt.JobRunMetadata {
+	r.runMutex.RLock()
+	defer r.runMutex.RUnlock()
+
+	jobRunsMetadata := []opt.JobRunMetadata{}
+
+	for _, jobRunMetadata := range r.runs {
+		startedAt, _ := time.Parse(time.RFC3339Nano, jobRunMetadata.StartedAt)
+		jobRunMetadata.Duration = time.Since(startedAt).Milliseconds()
+
+		jobRunsMetadata = append(jobRunsMetadata, jobRunMetadata)
+	}
+
+	sort.Slice(jobRunsMetadata, func(i, j int) bool {
+		return jobRunsMetadata[i].StartedAt < jobRunsMetadata[j].StartedAt
+	})
+
+	return jobRunsMetadata
+}
+
+func (r jobRegistry) Trigger(name string) error {
+	entry, err := r.entry(name)
+	if err != nil {
+		return err
+	}
+
+	if r.stopping.Load() {
+		return cons.JOB_SHUTDOWN
+	}
+
+	if !r.isLeader(entry.job) {
+		return cons.JOB_STANDBY
+	}
+
+	if entry.job.Overlap == cons.OVERLAP_SKIP && r.running()[name] > 0 {
+		return cons.JOB_ALREADY_RUNNING
+	}
+
+	go r.execute(entry, cons.JOB_TRIGGER_MANUAL)
+
+	pkg.Logrus(cons.INFO, "Job %s is triggered manually", name)
+	return nil
+}
+
+/**
+* Pause and resume are stored in redis instead of stopping the local scheduler, every instance keep its scheduler
+* running and the one holding the lease check the stored state before a scheduled run
+ */
+func (r jobRegistry) Pause(name string) error {
+	if _, err := r.entry(name); err != nil {
+		return err
+	}
+
+	added, err := r.rds.SAdd(r.ctx, cons.JOB_PAUSED_KEY, name).Result()
+	if err != nil {
+		return err
+	}
+
+	if added > 0 {
+		pkg.Logrus(cons.INFO, "Job %s is paused", name)
+	}
+
+	return nil
+}
+
+func (r jobRegistry) Resume(name string) error {
+	if _, err := r.entry(name); err != nil {
+		return err
+	}
+
+	removed, err := r.rds.SRem(r.ctx, cons.JOB_PAUSED_KEY, name).Result()
+	if err != nil {
+		return err
+	}
+
+	if removed > 0 {
+		pkg.Logrus(cons.INFO, "Job %s is resumed", name)
+	}
+
+	return nil
+}
+
+func (r jobRegistry) paused() (map[string]bool, error) {
+	names, err := r.rds.SMembers(r.ctx, cons.JOB_PAUSED_KEY).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]bool, len(names))
+	for _, name := range names {
+		res[name] = true
+	}
+
+	return res, nil
+}
+
+func (r jobRegistry) isPaused(job dto.Job) (bool, error) {
+	return r.rds.SIsMember(r.ctx, cons.JOB_PAUSED_KEY, job.Name).Result()
+}
+
+/**
+* Shutdown stop every scheduler so no new run is started and wait the running jobs until the timeout,
+* job still running after the timeout is canceled by the caller context, the leases are released last
+* so the standby take over right away instead of waiting the lease ttl
+ */
+func (r jobRegistry) Shutdown(timeout time.Duration) error {
+	if !r.stopping.CompareAndSwap(false, true) {
+		return cons.JOB_SHUTDOWN
+	}
+
+	r.mutex.RLock()
+
+	for _, entry := range r.jobs {
+		go func() {
+			if err := entry.scheduler.StopJobs(); err != nil && !errors.Is(err, gocron.ErrStopSchedulerTimedOut) {
+				pkg.Logrus(cons.ERROR, err)
+			}
+		}()
+	}
+
+	r.mutex.RUnlock()
+
+	pkg.Logrus(cons.INFO, "Job registry is shutting down, waiting %d running job", len(r.Runs()))
+
+	defer r.releaseLeases()
+
+	deadline := time.Now().Add(timeout)
+
+	for len(r.Runs()) > 0 {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: %d job still running", cons.JOB_SHUTDOWN_TIMEOUT, len(r.Runs()))
+		}
+
+		time.Sleep(time.Duration(time.Millisecond * 100))
+	}
+
+	return nil
+}
+
+func (r jobRegistry) releaseLeases() {
+	r.release()
+	r.released.Wait()
+
+	pkg.Logrus(cons.INFO, "Job registry released %d lease", len(r.leases))
+}
+
+func (r jobRegistry) entry(name string) (*jobEntry, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	entry, ok := r.jobs[name]
+	if !ok {
+		return nil, cons.JOB_NOT_FOUND
+	}
+
+	return entry, nil
+}
+
+func (r jobRegistry) running() map[string]int {
+	r.runMutex.RLock()
+	defer r.runMutex.RUnlock()
+
+	res := make(map[string]int)
+	for _, jobRunMetadata := range r.runs {
+		res[jobRunMetadata.Name]++
+	}
+
+	return res
+}
+
+func (r jobRegistry) track(id string, job dto.Job, trigger string, startedAt time.Time) func() {
+	r.runMutex.Lock()
+	r.runs[id] = opt.JobRunMetadata{ID: id, Name: job.Name, Trigger: trigger, StartedAt: startedAt.Format(time.RFC3339Nano)}
+	r.runMutex.Unlock()
+
+	return func() {
+		r.runMutex.Lock()
+		delete(r.runs, id)
+		r.runMutex.Unlock()
+	}
+}
+
+// fence return lease fence for the handler, the token of the first lock is the one written with the data
+func (r jobRegistry) fence(job dto.Job) func() (int64, error) {
+	return func() (int64, error) {
+		var token int64
+
+		for i, lock := range job.Locks {
+			fenced, err := r.leases[lock].Fence()
+			if err != nil {
+				return 0, err
+			}
+
+			if i == 0 {
+				token = fenced
+			}
+		}
+
+		return token, nil
+	}
+}
+
+func (r jobRegistry) isLeader(job dto.Job) bool {
+	for _, lock := range job.Locks {
+		if !r.leases[lock].IsLeader() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r jobRegistry) execute(entry *jobEntry, trigger string) {
+	job := entry.job
+
+	if r.stopping.Load() {
+		return
+	}
+
+	if !r.isLeader(job) {
+		pkg.Logrus(cons.INFO, "Job %s is standby, lease is held by another instance", job.Name)
+		return
+	}
+
+	if trigger == cons.JOB_TRIGGER_SCHEDULE {
+		paused, err := r.isPaused(job)
+		if err != nil {
+			pkg.Logrus(cons.ERROR, err)
+			return
+		}
+
+		if paused {
+			pkg.Logrus(cons.INFO, "Job %s is paused, scheduled run is skipped", job.Name)
+			return
+		}
+	}
+
+	switch job.Overlap {
+
+	case cons.OVERLAP_SKIP:
+		if !entry.running.TryLock() {
+			r.skip(entry)
+			return
+		}
+		defer entry.running.Unlock()
+		defer r.recordSkipped(entry)
+
+	case cons.OVERLAP_WAIT:
+		entry.running.Lock()
+		defer entry.running.Unlock()
+	}
+
+	startedAt := time.Now()
+	entry.lastRunAt.Store(startedAt.UnixNano())
+
+	runID, err := r.start(job, startedAt)
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+	}
+
+	trackID := runID
+	if trackID == "" {
+		trackID = shortuuid.New()
+	}
+
+	untrack := r.track(trackID, job, trigger, startedAt)
+	defer untrack()
+
+	ctx, cancel := r.context(job)
+	defer cancel()
+
+	pkg.Logrus(cons.INFO, "Job %s is running at %s by %s trigger", job.Name, startedAt.Format(cons.DATE_TIME_FORMAT), trigger)
+
+	status := cons.JOB_STATUS_SUCCESS
+	err = job.Handler(ctx, r.fence(job))
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		status = cons.JOB_STATUS_TIMEOUT
+		if err == nil {
+			err = ctx.Err()
+		}
+
+	} else if err != nil {
+		status = cons.JOB_STATUS_FAILED
+	}
+
+	if err != nil {
+		pkg.Logrus(cons.ERROR, "Job %s is %s: %v", job.Name, status, err)
+	}
+
+	if runID != "" {
+		if err := r.finish(runID, status, err); err != nil {
+			pkg.Logrus(cons.ERROR, err)
+		}
+	}
+}
+
+func (r jobRegistry) context(job dto.Job) (context.Context, context.CancelFunc) {
+	if job.Timeout > 0 {
+		return context.WithTimeout(r.ctx, job.Timeout)
+	}
+
+	return context.WithCancel(r.ctx)
+}
+
+func (r jobRegistry) start(job dto.Job, startedAt time.Time) (string, error) {
+	schedulerRunsRepositorie := repo.NewSchedulerRunsRepositorie(r.ctx, r.db)
+
+	schedulerRunsEntitie := entitie.SchedulerRunsEntitie{}
+	schedulerRunsEntitie.JobName = job.Name
+	schedulerRunsEntitie.Holder = r.holder
+	schedulerRunsEntitie.Status = cons.JOB_STATUS_RUNNING
+	schedulerRunsEntitie.StartedAt = startedAt
+
+	if err := schedulerRunsRepositorie.Insert(schedulerRunsEntitie, "id", &schedulerRunsEntitie.ID); err != nil {
+		return "", err
+	}
+
+	return schedulerRunsEntitie.ID, nil
+}
+
+func (r jobRegistry) finish(id string, status string, runErr error) error {
+	schedulerRunsRepositorie := repo.NewSchedulerRunsRepositorie(r.ctx, r.db)
+
+	schedulerRunsEntitie := entitie.SchedulerRunsEntitie{}
+	schedulerRunsEntitie.ID = id
+	schedulerRunsEntitie.Status = status
+	schedulerRunsEntitie.FinishedAt = zero.TimeFrom(time.Now())
+
+	if runErr != nil {
+		schedulerRunsEntitie.Error = runErr.Error()
+	}
+
+	return schedulerRunsRepositorie.Update(schedulerRunsEntitie)
+}
+
+/**
+* Skipped tick is only counted, the skips of one overlapping run are recorded as a single run once it finish
+* so a slow job on a short crontab does not write a row every tick
+ */
+func (r jobRegistry) skip(entry *jobEntry) {
+	entry.skippedAt.CompareAndSwap(0, time.Now().UnixNano())
+	entry.skips.Add(1)
+
+	if entry.skipped.Add(1) == 1 {
+		pkg.Logrus(cons.INFO, "Job %s is skipped, previous run is still in progress", entry.job.Name)
+	}
+}
+
+func (r jobRegistry) recordSkipped(entry *jobEntry) {
+	skipped := entry.skipped.Swap(0)
+	skippedAt := entry.skippedAt.Swap(0)
+
+	if skipped < 1 {
+		return
+	}
+
+	startedAt := time.Now()
+	if skippedAt > 0 {
+		startedAt = time.Unix(0, skippedAt)
+	}
+
+	r.record(entry.job, cons.JOB_STATUS_SKIPPED, fmt.Errorf("%d run skipped while previous run was in progress", skipped), startedAt)
+}
+
+func (r jobRegistry) record(job dto.Job, status string, runErr error, startedAt time.Time) {
+	runID, err := r.start(job, startedAt)
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		return
+	}
+
+	if err := r.finish(runID, status, runErr); err != nil {
+		pkg.Logrus(cons.ERROR, err)
+	}
+}
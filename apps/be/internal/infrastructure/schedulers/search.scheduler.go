@@ -2,10 +2,11 @@ package scheduler
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
-	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/guregu/null/v6/zero"
 	"github.com/meilisearch/meilisearch-go"
 	"github.com/redis/go-redis/v9"
@@ -22,26 +23,23 @@ import (
 )
 
 type searchScheduler struct {
-	ctx  context.Context
-	env  dto.Request[dto.Environtment]
-	db   *bun.DB
-	rds  *redis.Client
-	amqp *rabbitmq.Conn
-	mls  meilisearch.ServiceManager
+	ctx   context.Context
+	env   dto.Request[dto.Environtment]
+	db    *bun.DB
+	rds   *redis.Client
+	amqp  *rabbitmq.Conn
+	mls   meilisearch.ServiceManager
+	fence func() (int64, error)
 }
 
 func NewSearchScheduler(options dto.SchedulerOptions) inf.ISearchScheduler {
 	return searchScheduler{ctx: options.CTX, env: options.ENV, db: options.DB, rds: options.RDS, amqp: options.AMQP, mls: options.MLS}
 }
 
-func (s searchScheduler) findAllUsers(wg *sync.WaitGroup, startAt string, usersEntitiesChan chan []entitie.UsersEntitie, errChan chan error) (*sync.WaitGroup, string, chan []entitie.UsersEntitie, chan error) {
-	defer wg.Done()
-
+func (s searchScheduler) findAllUsers(startAt string, limit int) ([]entitie.UsersEntitie, error) {
 	usersRepositorie := repo.NewUsersRepositorie(s.ctx, s.db)
 	usersEntities := []entitie.UsersEntitie{}
 
-	limit := 500
-
 	err := usersRepositorie.Find().Column("*").
 		Where("deleted_at IS NULL AND is_sync = ?", cons.FALSE).
 		WhereGroup(cons.AND, func(sqlb *bun.SelectQuery) *bun.SelectQuery {
@@ -54,202 +52,284 @@ func (s searchScheduler) findAllUsers(wg *sync.WaitGroup, startAt string, usersE
 		Limit(limit).Scan(s.ctx, &usersEntities)
 
 	if err != nil {
-		errChan <- err
-		return wg, "", usersEntitiesChan, errChan
+		return nil, err
 	}
 
-	usersEntitiesChan <- usersEntities
 	pkg.Logrus(cons.INFO, "Found total data %d in postgres", len(usersEntities))
-
-	return wg, startAt, usersEntitiesChan, errChan
+	return usersEntities, nil
 }
 
-func (s searchScheduler) updateUsers(wg *sync.WaitGroup, startAt string, usersEntitiesChan chan []entitie.UsersEntitie, errChan chan error) (*sync.WaitGroup, chan []entitie.UsersEntitie, chan error) {
-	defer wg.Done()
+/**
+* Meilisearch has no conditional write, so the fence is checked against the high water mark right before the
+* documents are sent, the returned token is written to postgres where the row itself reject an older token
+ */
+func (s searchScheduler) upsertUsers(usersEntities []entitie.UsersEntitie) (int64, error) {
+	usersRepositorie := repo.NewUsersMeilisearchRepositorie(s.ctx, s.mls)
 
-	if usersEntities := <-usersEntitiesChan; len(usersEntities) > 0 {
-		cdcTimeUnix, err := helper.TimeStampToUnix(startAt)
-		if err != nil {
-			errChan <- err
-			return wg, usersEntitiesChan, errChan
-		}
+	ids := make([]string, 0, len(usersEntities))
+	for _, userEntity := range usersEntities {
+		ids = append(ids, userEntity.ID)
+	}
 
-		usersRepositorie := repo.NewUsersMeilisearchRepositorie(s.ctx, s.mls)
+	filterFindDocQuery := meilisearch.DocumentsQuery{Filter: helper.FilterIn("id", ids...), Fields: []string{"id"}, Limit: int64(len(ids))}
+	usersFetchDocuments, err := usersRepositorie.Find(&filterFindDocQuery)
+	if err != nil {
+		return 0, err
+	}
+
+	usersDocExists := make(map[string]bool, len(usersFetchDocuments.Results))
+	for _, usersFetchDocument := range usersFetchDocuments.Results {
+		usersDocExists[usersFetchDocument.ID] = true
+	}
 
-		var insertDocFound, updateDocFound *bool
+	usersDocEntities := make([]entitie.UsersDocument, 0, len(usersEntities))
+	totalUpdated := 0
+
+	for _, userEntity := range usersEntities {
 		usersDocEntitie := entitie.UsersDocument{}
-		usersUpdateDocEntities := []entitie.UsersDocument{}
-		usersInsertDocEntities := []entitie.UsersDocument{}
-
-		for _, userEntity := range usersEntities {
-			usersDocEntitie.ID = userEntity.ID
-			usersDocEntitie.Name = userEntity.Name
-			usersDocEntitie.Email = userEntity.Email
-			usersDocEntitie.Phone = userEntity.Phone
-			usersDocEntitie.DateOfBirth = userEntity.DateOfBirth
-			usersDocEntitie.Age = userEntity.Age
-			usersDocEntitie.Address = userEntity.Address
-			usersDocEntitie.City = userEntity.City
-			usersDocEntitie.State = userEntity.State
-			usersDocEntitie.Direction = userEntity.Direction
-			usersDocEntitie.Country = userEntity.Country
-			usersDocEntitie.PostalCode = userEntity.PostalCode
-
-			createdAtFilter := fmt.Sprintf("updated_at IS NULL AND created_at > %d", cdcTimeUnix)
-			updatedAtFilter := fmt.Sprintf("updated_at IS NOT NULL AND updated_at > %d", cdcTimeUnix)
-
-			filter := fmt.Sprintf("deleted_at IS NULL AND id = '%s' AND (%s) OR (%s)", usersDocEntitie.ID, createdAtFilter, updatedAtFilter)
-			fields := []string{
-				"id",
-				"name",
-				"email",
-				"phone",
-				"date_of_birth",
-				"age",
-				"address",
-				"city",
-				"state",
-				"direction",
-				"country",
-				"postal_code",
-				"created_at",
-				"updated_at",
-				"deleted_at",
+		usersDocEntitie.ID = userEntity.ID
+		usersDocEntitie.Name = userEntity.Name
+		usersDocEntitie.Email = userEntity.Email
+		usersDocEntitie.Phone = userEntity.Phone
+		usersDocEntitie.DateOfBirth = userEntity.DateOfBirth
+		usersDocEntitie.MaskedEmail = helper.MaskEmail(userEntity.Email)
+		usersDocEntitie.MaskedPhone = helper.MaskPhone(userEntity.Phone)
+		usersDocEntitie.MaskedDateOfBirth = helper.MaskDate(userEntity.DateOfBirth)
+		usersDocEntitie.Age = userEntity.Age
+		usersDocEntitie.Address = userEntity.Address
+		usersDocEntitie.City = userEntity.City
+		usersDocEntitie.State = userEntity.State
+		usersDocEntitie.Direction = userEntity.Direction
+		usersDocEntitie.Country = userEntity.Country
+		usersDocEntitie.PostalCode = userEntity.PostalCode
+		usersDocEntitie.CreatedAt = userEntity.CreatedAt.Unix()
+
+		if usersDocExists[userEntity.ID] {
+			totalUpdated++
+
+			if userEntity.UpdatedAt.Valid {
+				usersDocEntitie.UpdatedAt = userEntity.UpdatedAt.Time.Unix()
 			}
+		}
 
-			filterFindDocQuery := meilisearch.DocumentsQuery{Filter: filter, Fields: fields, Offset: 0, Limit: 1000}
-			usersFetchDocuments, err := usersRepositorie.Find(&filterFindDocQuery)
+		usersDocEntities = append(usersDocEntities, usersDocEntitie)
+	}
 
-			if err != nil {
-				errChan <- err
-				return wg, usersEntitiesChan, errChan
-			}
+	token, err := s.fence()
+	if err != nil {
+		return 0, err
+	}
+
+	// update documents in meilisearch is upsert, missing document is inserted and existing document is merged
+	if err := usersRepositorie.BulkUpdate(usersDocEntities); err != nil {
+		return 0, err
+	}
+
+	pkg.Logrus(cons.INFO, "Total data %d inserted and %d updated to meilisearch success", len(usersDocEntities)-totalUpdated, totalUpdated)
+	return token, nil
+}
 
-			if usersFetchDocuments.Results != nil {
-				isTrue := true
-				updateDocFound = &isTrue
+func (s searchScheduler) markUsersAsSync(usersEntities []entitie.UsersEntitie, token int64) error {
+	usersRepositorie := repo.NewUsersRepositorie(s.ctx, s.db)
+
+	ids := make([]string, 0, len(usersEntities))
+	for _, userEntity := range usersEntities {
+		ids = append(ids, userEntity.ID)
+	}
 
-				usersDocEntitie.UpdatedAt, err = helper.TimeStampToUnix(userEntity.UpdatedAt.Time.Format(time.RFC3339))
-				if err != nil {
-					errChan <- err
-					return wg, usersEntitiesChan, errChan
-				}
+	usersEntitie := entitie.UsersEntitie{}
+	usersEntitie.UpdatedAt = zero.TimeFrom(time.Now())
+	usersEntitie.IsSync = cons.TRUE
 
-				usersUpdateDocEntities = append(usersUpdateDocEntities, usersDocEntitie)
-			} else {
-				isTrue := true
-				insertDocFound = &isTrue
+	// rows already marked by a holder with a newer token are skipped by the repositorie
+	if err := usersRepositorie.BulkFencedUpdate(usersEntitie, token, ids...); err != nil && err != cons.NO_ROWS_AFFECTED {
+		return err
+	}
 
-				usersDocEntitie.CreatedAt, err = helper.TimeStampToUnix(userEntity.CreatedAt.Format(time.RFC3339))
-				if err != nil {
-					errChan <- err
-					return wg, usersEntitiesChan, errChan
-				}
+	pkg.Logrus(cons.INFO, "Total data %d users from postgres mark as sync", len(ids))
+	return nil
+}
 
-				usersInsertDocEntities = append(usersInsertDocEntities, usersDocEntitie)
-			}
+func (s searchScheduler) syncUsers(startAt string) error {
+	limit := s.env.Config.SCHEDULER.BATCH_SIZE
+	if limit < 1 {
+		limit = 500
+	}
+
+	total := 0
+
+	for {
+		if err := s.ctx.Err(); err != nil {
+			return err
 		}
 
-		if updateDocFound != nil && *updateDocFound {
-			pkg.Logrus(cons.INFO, "Total data %d updated to meilisearch success", len(usersUpdateDocEntities))
-			if err := usersRepositorie.BulkUpdate(usersUpdateDocEntities); err != nil {
-				errChan <- err
-				return wg, usersEntitiesChan, errChan
-			}
-			updateDocFound = nil
+		usersEntities, err := s.findAllUsers(startAt, limit)
+		if err != nil {
+			return err
+		}
 
+		if len(usersEntities) < 1 {
+			break
 		}
 
-		if insertDocFound != nil && *insertDocFound {
-			pkg.Logrus(cons.INFO, "Total data %d inserted to meilisearch success", len(usersInsertDocEntities))
-			if err := usersRepositorie.BulkInsert(usersInsertDocEntities); err != nil {
-				errChan <- err
-				return wg, usersEntitiesChan, errChan
-			}
-			insertDocFound = nil
+		token, err := s.upsertUsers(usersEntities)
+		if err != nil {
+			return err
+		}
+
+		if err := s.markUsersAsSync(usersEntities, token); err != nil {
+			return err
 		}
 
-		if usersUpdateDocEntities != nil || usersInsertDocEntities != nil {
-			usersEntitiesChan <- usersEntities
+		total += len(usersEntities)
+
+		if len(usersEntities) < limit {
+			break
 		}
 	}
 
-	return wg, usersEntitiesChan, errChan
+	if total > 0 {
+		pkg.Logrus(cons.INFO, "Total data %d synced to meilisearch success", total)
+	}
+
+	return nil
 }
 
-func (s searchScheduler) markUsersAsSync(wg *sync.WaitGroup, usersEntitiesChan chan []entitie.UsersEntitie, errChan chan error) {
-	defer wg.Done()
+func (s searchScheduler) deleteUsers(startAt string) error {
+	key := "SCHEDULER:SEARCH:DELETE:CDC"
+	limit := 500
+
+	usersRepositorie := repo.NewUsersRepositorie(s.ctx, s.db)
+	usersDocRepositorie := repo.NewUsersMeilisearchRepositorie(s.ctx, s.mls)
+	usersEntities := []entitie.UsersEntitie{}
 
-	if usersEntities := <-usersEntitiesChan; len(usersEntities) > 0 {
-		usersRepositorie := repo.NewUsersRepositorie(s.ctx, s.db)
-		usersEntitie := entitie.UsersEntitie{}
+	deletedAt, id := startAt, cons.InvalidUUID
 
-		for _, userEntity := range usersEntities {
-			usersEntitie.ID = userEntity.ID
-			usersEntitie.UpdatedAt = zero.TimeFrom(time.Now())
-			usersEntitie.IsSync = cons.TRUE
+	watermark, err := s.rds.HMGet(s.ctx, key, "deleted_at", "id").Result()
+	if err != nil {
+		return err
+	}
 
-			if err := usersRepositorie.Update(usersEntitie, "id", "is_sync", &usersEntitie.ID, &usersEntitie.IsSync); err != nil {
-				errChan <- err
-				return
-			}
+	if watermark[0] != nil && watermark[1] != nil {
+		deletedAt, id = watermark[0].(string), watermark[1].(string)
+	}
 
-			pkg.Logrus(cons.INFO, "Data users %s from postgres mark as sync: %v", usersEntitie.ID, usersEntitie.IsSync)
-		}
+	err = usersRepositorie.Find().Column("id", "deleted_at").
+		Where("deleted_at IS NOT NULL").
+		Where("(deleted_at, id) > (?, ?)", deletedAt, id).
+		Order("deleted_at ASC", "id ASC").
+		Limit(limit).Scan(s.ctx, &usersEntities)
+
+	if err != nil {
+		return err
+	}
+
+	if len(usersEntities) < 1 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(usersEntities))
+	deletedAtUnix := make(map[string]int64, len(usersEntities))
+
+	for _, userEntity := range usersEntities {
+		ids = append(ids, userEntity.ID)
+		deletedAtUnix[userEntity.ID] = userEntity.DeletedAt.Time.Unix()
+	}
+
+	if _, err := s.fence(); err != nil {
+		return err
+	}
+
+	switch s.env.Config.SCHEDULER.DELETE_MODE {
+
+	case cons.DELETE_MODE_HARD:
+		err = usersDocRepositorie.BulkDestroy(ids...)
+
+	default:
+		err = usersDocRepositorie.BulkDelete(deletedAtUnix)
 	}
+
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	if err := s.auditDeleteUsers(usersEntities); err != nil {
+		return err
+	}
+
+	lastEntity := usersEntities[len(usersEntities)-1]
+	if err := s.rds.HSet(s.ctx, key, "deleted_at", lastEntity.DeletedAt.Time.Format(time.RFC3339Nano), "id", lastEntity.ID).Err(); err != nil {
+		return err
+	}
+
+	pkg.Logrus(cons.INFO, "Total data %d deleted from meilisearch success", len(ids))
+	return nil
+}
+
+/**
+* Users are soft deleted outside of the api, so the delete is recorded here once it is propagated, entry id is
+* derived from the user and deleted time so the batch retried before the watermark is saved is not recorded twice
+ */
+func (s searchScheduler) auditDeleteUsers(usersEntities []entitie.UsersEntitie) error {
+	auditLogsRepositorie := repo.NewAuditLogsRepositorie(s.ctx, s.db)
+	auditLogsEntities := make([]entitie.AuditLogsEntitie, 0, len(usersEntities))
+
+	for _, usersEntitie := range usersEntities {
+		deletedAt := usersEntitie.DeletedAt.Time
+
+		auditLogsEntitie := entitie.AuditLogsEntitie{}
+		auditLogsEntitie.ID = uuid.NewSHA1(uuid.NameSpaceOID, []byte(usersEntitie.ID+deletedAt.Format(time.RFC3339Nano))).String()
+		auditLogsEntitie.Actor = cons.AUDIT_SCHEDULER_ACTOR
+		auditLogsEntitie.ActorType = cons.AUDIT_ACTOR_SYSTEM
+		auditLogsEntitie.Action = cons.AUDIT_ACTION_DELETE
+		auditLogsEntitie.TargetType = cons.AUDIT_TARGET_USERS
+		auditLogsEntitie.TargetID = usersEntitie.ID
+		auditLogsEntitie.Changes = map[string]any{"deleted_at": dto.AuditLogChange{Before: nil, After: deletedAt}}
+		auditLogsEntitie.Metadata = map[string]any{"delete_mode": s.env.Config.SCHEDULER.DELETE_MODE}
+		auditLogsEntitie.OccurredAt = deletedAt
+
+		auditLogsEntities = append(auditLogsEntities, auditLogsEntitie)
+	}
+
+	return auditLogsRepositorie.Insert(auditLogsEntities...)
 }
 
-func (s searchScheduler) searchHandler(rds inf.IRedis) {
+func (s searchScheduler) searchHandler(rds inf.IRedis) error {
 	key := "WORKER:SEARCH:CDC"
 
 	isExists, err := rds.Exists(key)
 	if err != nil {
-		pkg.Logrus(cons.ERROR, err)
-		return
+		return err
 	}
 
 	if isExists > 0 {
 		result, err := rds.Get(key)
 		if err != nil {
-			pkg.Logrus(cons.ERROR, err)
-			return
+			return err
 		}
 
 		start_at := string(result)
-		wg := new(sync.WaitGroup)
-
-		usersEntitiesChan := make(chan []entitie.UsersEntitie, 3)
-		errChan := make(chan error)
-
-		// because this is unbuffer channel must be wrap with gorutine
-		go func() {
-			select {
-			case err := <-errChan:
-				if err != nil {
-					pkg.Logrus(cons.ERROR, err)
-					return
-				}
-			}
-		}()
 
-		wg.Add(3)
-		go s.markUsersAsSync(s.updateUsers(s.findAllUsers(wg, start_at, usersEntitiesChan, errChan)))
-		wg.Wait()
+		if err := s.syncUsers(start_at); err != nil {
+			return err
+		}
 
-		close(usersEntitiesChan)
-		close(errChan)
+		if err := s.deleteUsers(start_at); err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
-func (s searchScheduler) breakRun(rds inf.IRedis, handler func(rds inf.IRedis)) {
+func (s searchScheduler) breakRun(rds inf.IRedis, handler func(rds inf.IRedis) error) error {
 	key := "SCHEDULER:SEARCH:BREAK"
 	value := 1
 	sync := 10
 
 	result, err := rds.IncrBy(key, value)
 	if err != nil {
-		pkg.Logrus(cons.ERROR, err)
-		return
+		return err
 	}
 
 	if result >= sync {
@@ -258,51 +338,37 @@ func (s searchScheduler) breakRun(rds inf.IRedis, handler func(rds inf.IRedis))
 
 		ttl, err := rds.TTL(key)
 		if err != nil {
-			pkg.Logrus(cons.ERROR, err)
-			return
+			return err
 		}
 
 		if ttl < 1 {
 			if err := rds.SetEx(key, breakTime, result); err != nil {
-				pkg.Logrus(cons.ERROR, err)
-				return
+				return err
 			}
 
 		} else if ttl > 1 && ttl < 3 {
 			if err := rds.Set(key, value); err != nil {
-				pkg.Logrus(cons.ERROR, err)
-				return
+				return err
 			}
 
 		}
 	}
 
 	if result <= sync {
-		handler(rds)
+		return handler(rds)
 	}
-}
 
-func (s searchScheduler) SearchRun() {
-	cron := pkg.NewCron()
+	return nil
+}
 
-	crontime := cons.Every30Seconds
-	now := time.Now().Format(cons.DATE_TIME_FORMAT)
+func (s searchScheduler) SearchHandler(ctx context.Context, fence func() (int64, error)) error {
+	s.ctx = ctx
+	s.fence = fence
 
 	rds, err := pkg.NewRedis(s.ctx, s.rds)
 	if err != nil {
-		pkg.Logrus(cons.ERROR, err)
-		return
-	}
-
-	sch, _, err := cron.Handler("search scheduler", crontime, func() {
-		pkg.Logrus(cons.INFO, fmt.Sprintf("Search scheduler is running %s - and execute at %s", now, crontime))
-		s.breakRun(rds, s.searchHandler)
-	})
-
-	if err != nil {
-		pkg.Logrus(cons.ERROR, err)
-		return
+		return err
 	}
 
-	sch.Start()
+	return s.breakRun(rds, s.searchHandler)
 }
@@ -0,0 +1,164 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/meilisearch/meilisearch-go"
+	"github.com/redis/go-redis/v9"
+	"github.com/uptrace/bun"
+	"github.com/wagslane/go-rabbitmq"
+
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	repo "github.com/restuwahyu13/go-fast-search/domain/repositories"
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+type auditWorker struct {
+	ctx  context.Context
+	env  dto.Request[dto.Environtment]
+	db   *bun.DB
+	rds  *redis.Client
+	amqp *rabbitmq.Conn
+	mls  meilisearch.ServiceManager
+	jobs inf.IConsumerRegistry
+}
+
+func NewAuditWorker(options dto.WorkerOptions, jobs inf.IConsumerRegistry) inf.IAuditWorker {
+	return auditWorker{ctx: options.CTX, env: options.ENV, db: options.DB, rds: options.RDS, amqp: options.AMQP, mls: options.MLS, jobs: jobs}
+}
+
+func (w auditWorker) auditBrokerInstance() inf.IBroker {
+	return pkg.NewBroker(w.ctx, w.env, w.amqp, w.rds)
+}
+
+func (w auditWorker) auditDeadLetterQueue(broker inf.IBroker, retry inf.IRetry, d dto.BrokerDelivery, origin dto.Request[dto.BrokerOptions], req *dto.RabbitDeadLetterQueueOptions) error {
+	req.Exchange = origin.Option.ExchangeName
+	req.ExchangeType = origin.Option.ExchangeType
+	req.Queue = origin.Option.QueueName
+	req.Attempt = retry.Attempt(d.Headers)
+	req.DeadLetterID = d.Headers[cons.X_DEAD_LETTER_ID]
+
+	amqp_req := dto.Request[dto.BrokerOptions]{}
+	amqp_req.Option.ExchangeName = req.Exchange
+	amqp_req.Option.ExchangeType = req.ExchangeType
+	amqp_req.Option.QueueName = req.Queue
+	amqp_req.Option.Body = req.Body
+
+	amqp_body := amqp_req
+
+	amqp_req.Option.ExchangeName = cons.EXCHANGE_NAME_DEAD_LETTER_QUEUE
+	amqp_req.Option.ExchangeType = cons.EXCHANGE_TYPE_DIRECT
+	amqp_req.Option.QueueName = cons.QUEUE_NAME_DEAD_LETTER_QUEUE
+	amqp_req.Option.Body = amqp_body
+	amqp_req.Option.Args = map[string]any{
+		cons.X_MESSAGE_TTL:    15,
+		cons.X_RETRY_ATTEMPT:  req.Attempt,
+		cons.X_RETRY_ERROR:    req.Error.Error(),
+		cons.X_DEAD_LETTER_ID: req.DeadLetterID,
+	}
+
+	if err := broker.Publisher(amqp_req); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+/**
+* Entry id is the event id, so the event redelivered or replayed from the dead letter queue is written once,
+* event id that is not an uuid is turned into a stable uuid of it
+ */
+func (w auditWorker) auditWrite(event *dto.Event[map[string]any], req dto.AuditLog) error {
+	transform := helper.NewTransform()
+	auditLogsRepositorie := repo.NewAuditLogsRepositorie(w.ctx, w.db)
+
+	id, err := uuid.Parse(event.ID)
+	if err != nil {
+		id = uuid.NewSHA1(uuid.NameSpaceOID, []byte(event.ID))
+	}
+
+	auditLogsEntitie := entitie.AuditLogsEntitie{}
+	auditLogsEntitie.ID = id.String()
+	auditLogsEntitie.Actor = req.Actor
+	auditLogsEntitie.ActorType = req.ActorType
+	auditLogsEntitie.Action = req.Action
+	auditLogsEntitie.TargetType = req.TargetType
+	auditLogsEntitie.TargetID = req.TargetID
+	auditLogsEntitie.Metadata = req.Metadata
+	auditLogsEntitie.RequestID = req.RequestID
+	auditLogsEntitie.IPAddress = req.IPAddress
+	auditLogsEntitie.OccurredAt = req.OccurredAt
+
+	if len(req.Changes) > 0 {
+		if err := transform.SrcToDest(req.Changes, &auditLogsEntitie.Changes); err != nil {
+			return err
+		}
+	}
+
+	return auditLogsRepositorie.Insert(auditLogsEntitie)
+}
+
+func (w auditWorker) auditConsumer() {
+	broker := w.auditBrokerInstance()
+	retry := pkg.NewRetry(w.env)
+	events := pkg.NewEventRegistry()
+
+	amqp_req := dto.Request[dto.BrokerOptions]{}
+
+	amqp_req.Option.ExchangeName = cons.EXCHANGE_NAME_AUDIT
+	amqp_req.Option.ExchangeType = cons.EXCHANGE_TYPE_DIRECT
+	amqp_req.Option.QueueName = cons.QUEUE_NAME_AUDIT
+	amqp_req.Option.Concurrency = 1
+	amqp_req.Option.Prefetch = 1
+
+	err := w.jobs.Consume(broker, cons.JOB_NAME_AUDIT, amqp_req, func(d dto.BrokerDelivery) dto.BrokerAction {
+		transform := helper.NewTransform()
+
+		dlq_req := dto.RabbitDeadLetterQueueOptions{}
+		req := dto.AuditLog{}
+
+		event, err := events.Decode(d.Body)
+		if err != nil {
+			pkg.Logrus(cons.ERROR, err)
+			return cons.BROKER_NACK_DISCARD
+		}
+
+		if event.Type != cons.EVENT_TYPE_AUDIT_LOG {
+			pkg.Logrus(cons.ERROR, "Event %s with type %s is not an audit entry", event.ID, event.Type)
+			return cons.BROKER_NACK_DISCARD
+		}
+
+		if err := transform.SrcToDest(event.Data, &req); err != nil {
+			return cons.BROKER_NACK_DISCARD
+		}
+
+		if err := w.auditWrite(event, req); err != nil {
+			pkg.Logrus(cons.ERROR, "Audit entry %s is not written: %v", event.ID, err)
+
+			dlq_req.Body = event
+			dlq_req.Error = err
+
+			if err := w.auditDeadLetterQueue(broker, retry, d, amqp_req, &dlq_req); err != nil {
+				pkg.Logrus(cons.ERROR, err)
+				return cons.BROKER_NACK_REQUEUE
+			}
+
+			return cons.BROKER_ACK
+		}
+
+		return cons.BROKER_ACK
+	})
+
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+	}
+}
+
+func (w auditWorker) AuditRun() {
+	w.auditConsumer()
+}
@@ -3,13 +3,15 @@ package worker
 import (
 	"context"
 	"fmt"
-	"strings"
 
+	"github.com/google/uuid"
 	"github.com/meilisearch/meilisearch-go"
 	"github.com/redis/go-redis/v9"
 	"github.com/uptrace/bun"
 	"github.com/wagslane/go-rabbitmq"
 
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	repo "github.com/restuwahyu13/go-fast-search/domain/repositories"
 	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
 	"github.com/restuwahyu13/go-fast-search/shared/dto"
 	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
@@ -24,67 +26,156 @@ type deadLetterQueueWorker struct {
 	rds  *redis.Client
 	amqp *rabbitmq.Conn
 	mls  meilisearch.ServiceManager
+	jobs inf.IConsumerRegistry
 }
 
-func NewDeadLetterQueueWorker(options dto.WorkerOptions) inf.IDeadLetterQueueWorker {
-	return deadLetterQueueWorker{ctx: options.CTX, env: options.ENV, db: options.DB, rds: options.RDS, amqp: options.AMQP, mls: options.MLS}
+func NewDeadLetterQueueWorker(options dto.WorkerOptions, jobs inf.IConsumerRegistry) inf.IDeadLetterQueueWorker {
+	return deadLetterQueueWorker{ctx: options.CTX, env: options.ENV, db: options.DB, rds: options.RDS, amqp: options.AMQP, mls: options.MLS, jobs: jobs}
 }
 
-func (w deadLetterQueueWorker) deadLetterQueueRabbitInstance() inf.IRabbitMQ {
-	return pkg.NewRabbitMQ(w.ctx, w.amqp)
+func (w deadLetterQueueWorker) deadLetterQueueBrokerInstance() inf.IBroker {
+	return pkg.NewBroker(w.ctx, w.env, w.amqp, w.rds)
+}
+
+func (w deadLetterQueueWorker) deadLetterQueueMirror(id string, req dto.Request[dto.BrokerOptions], attempt int, status, lastErr string) error {
+	deadLettersRepositorie := repo.NewDeadLettersRepositorie(w.ctx, w.db)
+
+	deadLettersEntitie := entitie.DeadLettersEntitie{}
+	deadLettersEntitie.ID = id
+	deadLettersEntitie.Exchange = req.Option.ExchangeName
+	deadLettersEntitie.Queue = req.Option.QueueName
+	deadLettersEntitie.Attempt = attempt
+	deadLettersEntitie.Status = status
+	deadLettersEntitie.Error = lastErr
+
+	if payload, ok := req.Option.Body.(map[string]any); ok {
+		deadLettersEntitie.Payload = payload
+
+		document := payload
+		if data, ok := payload["data"].(map[string]any); ok && payload["specversion"] != nil {
+			document = data
+		}
+
+		if document["id"] != nil {
+			deadLettersEntitie.DocumentID = fmt.Sprint(document["id"])
+		}
+
+		if action, ok := document["action"].(string); ok {
+			deadLettersEntitie.Action = action
+		}
+	}
+
+	return deadLettersRepositorie.Upsert(deadLettersEntitie)
+}
+
+func (w deadLetterQueueWorker) deadLetterQueueRetry(broker inf.IBroker, retry inf.IRetry, id string, req dto.Request[dto.BrokerOptions], attempt int, lastErr string) error {
+	retry_req := dto.Request[dto.BrokerOptions]{}
+	retry_req.Option.ExchangeName = req.Option.ExchangeName
+	retry_req.Option.ExchangeType = req.Option.ExchangeType
+	retry_req.Option.QueueName = req.Option.QueueName
+	retry_req.Option.Body = req.Option.Body
+	retry_req.Option.Args = map[string]any{
+		cons.X_RETRY_ATTEMPT:  attempt,
+		cons.X_RETRY_ERROR:    lastErr,
+		cons.X_DEAD_LETTER_ID: id,
+	}
+
+	if err := broker.Delay(retry_req, attempt); err != nil {
+		return err
+	}
+
+	pkg.Logrus(cons.INFO, "Queue %s is retried with attempt %d after %s", req.Option.QueueName, attempt, retry.Delay(attempt))
+	return nil
+}
+
+func (w deadLetterQueueWorker) deadLetterQueueParking(broker inf.IBroker, id string, req dto.Request[dto.BrokerOptions], attempt int, lastErr string) error {
+	parking_req := dto.Request[dto.BrokerOptions]{}
+	parking_req.Option.ExchangeName = cons.EXCHANGE_NAME_PARKING
+	parking_req.Option.ExchangeType = cons.EXCHANGE_TYPE_DIRECT
+	parking_req.Option.QueueName = cons.QUEUE_NAME_PARKING
+	parking_req.Option.Body = req
+	parking_req.Option.Args = map[string]any{
+		cons.X_RABBIT_EXCHANGE: req.Option.ExchangeName,
+		cons.X_RABBIT_QUEUE:    req.Option.QueueName,
+		cons.X_RETRY_ATTEMPT:   attempt,
+		cons.X_RETRY_ERROR:     lastErr,
+		cons.X_DEAD_LETTER_ID:  id,
+	}
+
+	if err := broker.Publisher(parking_req); err != nil {
+		return err
+	}
+
+	pkg.Logrus(cons.ERROR, "Queue %s is parked after %d attempt: %s", req.Option.QueueName, attempt, lastErr)
+	return nil
 }
 
 func (w deadLetterQueueWorker) deadLetterQueueConsumer() {
-	amqp := w.deadLetterQueueRabbitInstance()
-	amqp_req := dto.Request[dto.RabbitOptions]{}
+	broker := w.deadLetterQueueBrokerInstance()
+	retry := pkg.NewRetry(w.env)
+
+	amqp_req := dto.Request[dto.BrokerOptions]{}
 
 	amqp_req.Option.ExchangeName = cons.EXCHANGE_NAME_DEAD_LETTER_QUEUE
 	amqp_req.Option.ExchangeType = cons.EXCHANGE_TYPE_DIRECT
 	amqp_req.Option.QueueName = cons.QUEUE_NAME_DEAD_LETTER_QUEUE
 	amqp_req.Option.Prefetch = 1
 
-	amqp.Consumer(amqp_req, func(d rabbitmq.Delivery) (action rabbitmq.Action) {
-		if d.Headers[cons.X_RABBIT_UNKNOWN] == cons.TRUE && d.Headers[cons.X_RABBIT_SECRET] != w.env.Config.RABBITMQ.SECRET {
-			pkg.Logrus(cons.INFO, "Queue is not allowed to be consumed: %s", string(d.Body))
-			return rabbitmq.NackDiscard
-		}
+	err := w.jobs.Consume(broker, cons.JOB_NAME_DEAD_LETTER_QUEUE, amqp_req, func(d dto.BrokerDelivery) (action dto.BrokerAction) {
+		req := dto.Request[dto.BrokerOptions]{}
 
 		parser := helper.NewParser()
-		if err := parser.Unmarshal(d.Body, &amqp_req); err != nil {
-			return rabbitmq.NackDiscard
+		if err := parser.Unmarshal(d.Body, &req); err != nil {
+			return cons.BROKER_NACK_DISCARD
 		}
 
-		if amqp_req.Option.Body != nil {
-			amqp_req.Option.Args = rabbitmq.Table{cons.X_RABBIT_SECRET: w.env.Config.RABBITMQ.SECRET, cons.X_MESSAGE_TTL: 15}
+		if req.Option.Body == nil {
+			return cons.BROKER_NACK_DISCARD
+		}
 
-			key := fmt.Sprintf("%s", strings.ToUpper(amqp_req.Option.QueueName))
-			req := dto.Request[dto.SleepBackoff]{}
+		attempt := retry.Attempt(d.Headers) + 1
+		lastErr, _ := d.Headers[cons.X_RETRY_ERROR].(string)
 
-			req.Body.Ctx = w.ctx
-			req.Config.Redis = w.rds
-			req.Body.Key = key
-			req.Body.Count = 1
-			req.Body.Retry = 5
-			req.Body.BackOffTime = 300
+		id, _ := d.Headers[cons.X_DEAD_LETTER_ID].(string)
+		if id == "" {
+			id = uuid.NewString()
+		}
 
-			_, err := helper.SleepBackoff[int](req, func() (int, error) {
-				if err := amqp.Publisher(amqp_req); err != nil {
-					return -1, err
-				}
+		status := cons.DEAD_LETTER_STATUS_RETRYING
+		if retry.Exhausted(attempt) {
+			status = cons.DEAD_LETTER_STATUS_PARKED
+			attempt--
+		}
 
-				pkg.Logrus(cons.INFO, "Queue is allowed to be consumed: %s", string(d.Body))
-				return 1, nil
-			})
+		if err := w.deadLetterQueueMirror(id, req, attempt, status, lastErr); err != nil {
+			if err == cons.NO_ROWS_AFFECTED {
+				pkg.Logrus(cons.INFO, "Dead letter %s is discarded, queue %s is dropped", id, req.Option.QueueName)
+				return cons.BROKER_ACK
+			}
+
+			pkg.Logrus(cons.ERROR, err)
+		}
 
-			if err != nil {
-				return rabbitmq.NackDiscard
+		if status == cons.DEAD_LETTER_STATUS_PARKED {
+			if err := w.deadLetterQueueParking(broker, id, req, attempt, lastErr); err != nil {
+				pkg.Logrus(cons.ERROR, err)
+				return cons.BROKER_NACK_REQUEUE
 			}
 
-			return rabbitmq.Ack
+			return cons.BROKER_ACK
 		}
 
-		return rabbitmq.NackDiscard
+		if err := w.deadLetterQueueRetry(broker, retry, id, req, attempt, lastErr); err != nil {
+			pkg.Logrus(cons.ERROR, err)
+			return cons.BROKER_NACK_REQUEUE
+		}
+
+		return cons.BROKER_ACK
 	})
+
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
+	}
 }
 
 func (w deadLetterQueueWorker) DeadLetterQueueRun() {
@@ -0,0 +1,385 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lithammer/shortuuid"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+	"github.com/restuwahyu13/go-fast-search/shared/pkg"
+)
+
+type (
+	consumerRegistry struct {
+		ctx       context.Context
+		instances int
+		mutex     *sync.RWMutex
+		consumers map[string]*consumerEntry
+		runMutex  *sync.RWMutex
+		runs      map[string]opt.JobRunMetadata
+		inflight  *atomic.Int64
+		stopping  *atomic.Bool
+	}
+
+	consumerEntry struct {
+		name         string
+		queue        string
+		broker       inf.IBroker
+		req          dto.Request[dto.BrokerOptions]
+		callback     func(d dto.BrokerDelivery) dto.BrokerAction
+		mutex        sync.Mutex
+		stops        []func()
+		paused       atomic.Bool
+		throttled    atomic.Bool
+		lastRunAt    atomic.Int64
+		backpressure atomic.Value
+	}
+)
+
+/**
+* Consumer registry supervise every consumer of the worker, it start the configured number of consumer
+* per queue and keep count of the delivery in flight so the shutdown can wait for them
+ */
+func NewConsumerRegistry(ctx context.Context, env dto.Request[dto.Environtment]) inf.IConsumerRegistry {
+	return consumerRegistry{
+		ctx:       ctx,
+		instances: max(env.Config.WORKER.CONSUMERS, 1),
+		mutex:     new(sync.RWMutex),
+		consumers: make(map[string]*consumerEntry),
+		runMutex:  new(sync.RWMutex),
+		runs:      make(map[string]opt.JobRunMetadata),
+		inflight:  new(atomic.Int64),
+		stopping:  new(atomic.Bool),
+	}
+}
+
+func (r consumerRegistry) Consume(broker inf.IBroker, name string, req dto.Request[dto.BrokerOptions], handler func(d dto.BrokerDelivery) dto.BrokerAction) error {
+	if err := r.Register(name, req.Option.QueueName); err != nil {
+		return err
+	}
+
+	entry, err := r.entry(name)
+	if err != nil {
+		return err
+	}
+
+	entry.mutex.Lock()
+	entry.broker = broker
+	entry.req = req
+	entry.callback = r.Handler(name, handler)
+	entry.mutex.Unlock()
+
+	r.start(entry)
+
+	return nil
+}
+
+/**
+* Start the consumers of the entry unless they are running, paused or throttled, the broker push
+* nothing to a consumer that is not started so no delivery is held while the entry is halted
+ */
+func (r consumerRegistry) start(entry *consumerEntry) {
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	if entry.broker == nil || len(entry.stops) > 0 || r.stopping.Load() || entry.paused.Load() || entry.throttled.Load() {
+		return
+	}
+
+	for range r.instances {
+		entry.stops = append(entry.stops, entry.broker.Consumer(entry.req, entry.callback))
+	}
+}
+
+func (r consumerRegistry) stop(entry *consumerEntry) int {
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	stops := len(entry.stops)
+
+	for _, stop := range entry.stops {
+		stop()
+	}
+
+	entry.stops = nil
+
+	return stops
+}
+
+/**
+* Halted entry cancel its consumers, the unsettled delivery go back to the queue and nothing wait in the
+* callback past the broker delivery timeout, the consumers are started again once neither flag is set
+ */
+func (r consumerRegistry) toggle(entry *consumerEntry) {
+	if entry.paused.Load() || entry.throttled.Load() {
+		r.stop(entry)
+		return
+	}
+
+	r.start(entry)
+}
+
+func (r consumerRegistry) Register(name, queue string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.consumers[name]; ok {
+		return fmt.Errorf("consumer %s already registered", name)
+	}
+
+	r.consumers[name] = &consumerEntry{name: name, queue: queue}
+	return nil
+}
+
+/**
+* Wrap consumer callback and keep count of the delivery in flight, delivery pushed before the consumer
+* is halted is requeued right away
+ */
+func (r consumerRegistry) Handler(name string, handler func(d dto.BrokerDelivery) dto.BrokerAction) func(d dto.BrokerDelivery) dto.BrokerAction {
+	return func(d dto.BrokerDelivery) (action dto.BrokerAction) {
+		/**
+		* Delivery already pushed when the consumers are stopped is requeued for another instance
+		 */
+		r.inflight.Add(1)
+
+		if r.stopping.Load() {
+			r.inflight.Add(-1)
+			return cons.BROKER_NACK_REQUEUE
+		}
+
+		/**
+		* Deferred delivery stay in flight until it is settled, so the shutdown also wait for the batch holding it
+		 */
+		done := sync.OnceFunc(func() { r.inflight.Add(-1) })
+		settle := d.Settle
+
+		d.Settle = func(action dto.BrokerAction) {
+			done()
+			settle(action)
+		}
+
+		defer func() {
+			if action != cons.BROKER_DEFER {
+				done()
+			}
+		}()
+
+		entry, err := r.entry(name)
+		if err != nil {
+			return handler(d)
+		}
+
+		if entry.paused.Load() || entry.throttled.Load() {
+			return cons.BROKER_NACK_REQUEUE
+		}
+
+		startedAt := time.Now()
+		entry.lastRunAt.Store(startedAt.UnixNano())
+
+		id := shortuuid.New()
+
+		r.runMutex.Lock()
+		r.runs[id] = opt.JobRunMetadata{ID: id, Name: name, Trigger: cons.JOB_TRIGGER_DELIVERY, StartedAt: startedAt.Format(time.RFC3339Nano)}
+		r.runMutex.Unlock()
+
+		defer func() {
+			r.runMutex.Lock()
+			delete(r.runs, id)
+			r.runMutex.Unlock()
+		}()
+
+		return handler(d)
+	}
+}
+
+func (r consumerRegistry) Jobs() []opt.JobMetadata {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	jobsMetadata := []opt.JobMetadata{}
+	running := r.running()
+
+	for _, entry := range r.consumers {
+		jobMetadata := opt.JobMetadata{}
+		jobMetadata.Name = entry.name
+		jobMetadata.Kind = cons.JOB_KIND_CONSUMER
+		jobMetadata.Queue = entry.queue
+		jobMetadata.IsLeader = true
+		jobMetadata.IsPaused = entry.paused.Load()
+		jobMetadata.IsThrottled = entry.throttled.Load()
+		jobMetadata.Running = running[entry.name]
+
+		if lastRunAt := entry.lastRunAt.Load(); lastRunAt > 0 {
+			jobMetadata.LastRunAt = time.Unix(0, lastRunAt).Format(time.RFC3339)
+		}
+
+		if backpressure, ok := entry.backpressure.Load().(inf.IBackpressure); ok {
+			metrics := backpressure.Metrics()
+			jobMetadata.Backpressure = &metrics
+		}
+
+		jobsMetadata = append(jobsMetadata, jobMetadata)
+	}
+
+	sort.Slice(jobsMetadata, func(i, j int) bool {
+		return jobsMetadata[i].Name < jobsMetadata[j].Name
+	})
+
+	return jobsMetadata
+}
+
+func (r consumerRegistry) Runs() []opt.JobRunMetadata {
+	r.runMutex.RLock()
+	defer r.runMutex.RUnlock()
+
+	jobRunsMetadata := []opt.JobRunMetadata{}
+
+	for _, jobRunMetadata := range r.runs {
+		startedAt, _ := time.Parse(time.RFC3339Nano, jobRunMetadata.StartedAt)
+		jobRunMetadata.Duration = time.Since(startedAt).Milliseconds()
+
+		jobRunsMetadata = append(jobRunsMetadata, jobRunMetadata)
+	}
+
+	sort.Slice(jobRunsMetadata, func(i, j int) bool {
+		return jobRunsMetadata[i].StartedAt < jobRunsMetadata[j].StartedAt
+	})
+
+	return jobRunsMetadata
+}
+
+func (r consumerRegistry) Trigger(name string) error {
+	if _, err := r.entry(name); err != nil {
+		return err
+	}
+
+	return cons.JOB_NOT_TRIGGERABLE
+}
+
+func (r consumerRegistry) Pause(name string) error {
+	entry, err := r.entry(name)
+	if err != nil {
+		return err
+	}
+
+	if entry.paused.CompareAndSwap(false, true) {
+		r.toggle(entry)
+		pkg.Logrus(cons.INFO, "Consumer %s is paused", name)
+	}
+
+	return nil
+}
+
+func (r consumerRegistry) Resume(name string) error {
+	entry, err := r.entry(name)
+	if err != nil {
+		return err
+	}
+
+	if entry.paused.CompareAndSwap(true, false) {
+		r.toggle(entry)
+		pkg.Logrus(cons.INFO, "Consumer %s is resumed", name)
+	}
+
+	return nil
+}
+
+/**
+* Throttle is driven by the backpressure monitor and kept apart from the manual pause,
+* so a drained backlog never resume a consumer paused by the operator
+ */
+func (r consumerRegistry) Throttle(name string, throttled bool) error {
+	entry, err := r.entry(name)
+	if err != nil {
+		return err
+	}
+
+	if entry.throttled.CompareAndSwap(!throttled, throttled) {
+		r.toggle(entry)
+		pkg.Logrus(cons.INFO, "Consumer %s throttled is %t", name, throttled)
+	}
+
+	return nil
+}
+
+/**
+* Backpressure attach the monitor throttling the consumer, so the measured backlog, the thresholds
+* and the throttle state are listed with the consumer
+ */
+func (r consumerRegistry) Backpressure(name string, backpressure inf.IBackpressure) error {
+	entry, err := r.entry(name)
+	if err != nil {
+		return err
+	}
+
+	entry.backpressure.Store(backpressure)
+
+	return nil
+}
+
+/**
+* Shutdown stop every consumer first so the broker stop pushing message, then wait until the delivery
+* in flight are settled or the timeout is reached, the connections are closed by the caller afterward
+ */
+func (r consumerRegistry) Shutdown(timeout time.Duration) error {
+	if !r.stopping.CompareAndSwap(false, true) {
+		return cons.JOB_SHUTDOWN
+	}
+
+	r.mutex.RLock()
+	entries := slices.Collect(maps.Values(r.consumers))
+	r.mutex.RUnlock()
+
+	stops := 0
+	for _, entry := range entries {
+		stops += r.stop(entry)
+	}
+
+	pkg.Logrus(cons.INFO, "Consumer registry stopped %d consumer, waiting %d delivery in flight", stops, r.inflight.Load())
+
+	deadline := time.Now().Add(timeout)
+
+	for r.inflight.Load() > 0 {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: %d delivery still in flight", cons.JOB_SHUTDOWN_TIMEOUT, r.inflight.Load())
+		}
+
+		time.Sleep(time.Duration(time.Millisecond * 100))
+	}
+
+	return nil
+}
+
+func (r consumerRegistry) entry(name string) (*consumerEntry, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	entry, ok := r.consumers[name]
+	if !ok {
+		return nil, cons.JOB_NOT_FOUND
+	}
+
+	return entry, nil
+}
+
+func (r consumerRegistry) running() map[string]int {
+	r.runMutex.RLock()
+	defer r.runMutex.RUnlock()
+
+	res := make(map[string]int)
+	for _, jobRunMetadata := range r.runs {
+		res[jobRunMetadata.Name]++
+	}
+
+	return res
+}
@@ -3,6 +3,8 @@ package worker
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/meilisearch/meilisearch-go"
@@ -24,14 +26,15 @@ type searchWorker struct {
 	rds  *redis.Client
 	amqp *rabbitmq.Conn
 	mls  meilisearch.ServiceManager
+	jobs inf.IConsumerRegistry
 }
 
-func NewSearchWorker(options dto.WorkerOptions) inf.ISearchWorker {
-	return searchWorker{ctx: options.CTX, env: options.ENV, db: options.DB, rds: options.RDS, amqp: options.AMQP, mls: options.MLS}
+func NewSearchWorker(options dto.WorkerOptions, jobs inf.IConsumerRegistry) inf.ISearchWorker {
+	return searchWorker{ctx: options.CTX, env: options.ENV, db: options.DB, rds: options.RDS, amqp: options.AMQP, mls: options.MLS, jobs: jobs}
 }
 
-func (w searchWorker) searchRabbitInstance() inf.IRabbitMQ {
-	return pkg.NewRabbitMQ(w.ctx, w.amqp)
+func (w searchWorker) searchBrokerInstance() inf.IBroker {
+	return pkg.NewBroker(w.ctx, w.env, w.amqp, w.rds)
 }
 
 func (w searchWorker) searchChangeDataCapture() error {
@@ -52,8 +55,14 @@ func (w searchWorker) searchChangeDataCapture() error {
 	return nil
 }
 
-func (w searchWorker) searchDeadLetterQueue(amqp inf.IRabbitMQ, req *dto.RabbitDeadLetterQueueOptions) error {
-	amqp_req := dto.Request[dto.RabbitOptions]{}
+func (w searchWorker) searchDeadLetterQueue(broker inf.IBroker, retry inf.IRetry, d dto.BrokerDelivery, origin dto.Request[dto.BrokerOptions], req *dto.RabbitDeadLetterQueueOptions) error {
+	req.Exchange = origin.Option.ExchangeName
+	req.ExchangeType = origin.Option.ExchangeType
+	req.Queue = origin.Option.QueueName
+	req.Attempt = retry.Attempt(d.Headers)
+	req.DeadLetterID = d.Headers[cons.X_DEAD_LETTER_ID]
+
+	amqp_req := dto.Request[dto.BrokerOptions]{}
 	amqp_req.Option.ExchangeName = req.Exchange
 	amqp_req.Option.ExchangeType = req.ExchangeType
 	amqp_req.Option.QueueName = req.Queue
@@ -65,20 +74,20 @@ func (w searchWorker) searchDeadLetterQueue(amqp inf.IRabbitMQ, req *dto.RabbitD
 	amqp_req.Option.ExchangeType = cons.EXCHANGE_TYPE_DIRECT
 	amqp_req.Option.QueueName = cons.QUEUE_NAME_DEAD_LETTER_QUEUE
 	amqp_req.Option.Body = amqp_body
-	amqp_req.Option.Args = rabbitmq.Table{
-		cons.X_RABBIT_SECRET:  req.Secret,
-		cons.X_RABBIT_UNKNOWN: req.Unknown,
-		cons.X_MESSAGE_TTL:    15,
+	amqp_req.Option.Args = map[string]any{
+		cons.X_RETRY_ATTEMPT:  req.Attempt,
+		cons.X_RETRY_ERROR:    req.Error.Error(),
+		cons.X_DEAD_LETTER_ID: req.DeadLetterID,
 	}
 
-	if err := amqp.Publisher(amqp_req); err != nil {
+	if err := broker.Publisher(amqp_req); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (w searchWorker) searchHandler(req dto.Request[dto.MeiliSearchDocuments[map[string]any]]) error {
+func (w searchWorker) searchWrite(req dto.Request[dto.MeiliSearchDocuments[map[string]any]]) error {
 	mls := pkg.NewMeiliSearch(w.ctx, w.mls)
 
 	switch req.Body.Action {
@@ -96,71 +105,307 @@ func (w searchWorker) searchHandler(req dto.Request[dto.MeiliSearchDocuments[map
 		return nil
 
 	case cons.DELETE:
-		if _, err := mls.Delete(req.Body.Doc, req.Body.ID.(string)); err != nil {
+		if _, err := mls.Delete(req.Body.Doc, req.Body.ID.(string), helper.DeletedAt(req.Body.Data)); err != nil {
 			return err
 		}
 		return nil
 
 	default:
-		return errors.New("Meilisearch unknown action")
+		return cons.MEILISEARCH_UNKNOWN_ACTION
+	}
+}
+
+/**
+* Without batcher the document is written right away, otherwise the outcome is received once the batch is written
+ */
+func (w searchWorker) searchHandler(batcher inf.IBatcher, req dto.Request[dto.MeiliSearchDocuments[map[string]any]]) <-chan error {
+	if batcher != nil {
+		return batcher.Add(req.Body.Doc, req.Body.Action, helper.DocumentKey(req.Body.ID, req.Body.Data), req.Body.Data)
+	}
+
+	res := make(chan error, 1)
+	res <- w.searchWrite(req)
+
+	return res
+}
+
+/**
+* Envelope time is the document version, legacy message has no envelope time so the document
+* updated_at or created_at is used instead, zero means the version is unknown and not checked
+ */
+func (w searchWorker) searchEventVersion(event *dto.Event[map[string]any]) int64 {
+	if event.Source != cons.EVENT_SOURCE_LEGACY {
+		return event.Time.UnixNano()
+	}
+
+	data, ok := event.Data["data"].(map[string]any)
+	if !ok {
+		return 0
+	}
+
+	for _, field := range []string{"updated_at", "created_at"} {
+		if timestamp, ok := data[field].(float64); ok && timestamp > 0 {
+			return time.Unix(int64(timestamp), 0).UnixNano()
+		}
+	}
+
+	return 0
+}
+
+func (w searchWorker) searchDropped(idempotency inf.IIdempotency, kind string, event *dto.Event[map[string]any]) {
+	total, err := idempotency.Count(kind)
+	if err != nil {
+		pkg.Logrus(cons.ERROR, err)
 	}
+
+	pkg.Logrus(cons.INFO, "Event %s is %s and dropped, correlation %s, total %s event %d", event.ID, kind, event.CorrelationID, kind, total)
 }
 
 func (w searchWorker) searchConsumer() {
-	amqp := w.searchRabbitInstance()
-	amqp_req := dto.Request[dto.RabbitOptions]{}
+	broker := w.searchBrokerInstance()
+	retry := pkg.NewRetry(w.env)
+	events := pkg.NewEventRegistry()
+	idempotency := pkg.NewIdempotency(w.ctx, w.rds, dto.IdempotencyOptions{
+		Name:       cons.IDEMPOTENCY_SEARCH_WORKER,
+		TTL:        time.Duration(time.Second * time.Duration(w.env.Config.WORKER.DEDUP_TTL)),
+		VersionTTL: time.Duration(time.Second * time.Duration(w.env.Config.WORKER.VERSION_TTL)),
+	})
+
+	partitions := helper.Partitions(w.env.Config.RABBITMQ.PARTITIONS)
+	prefetch := 1
+
+	/**
+	* Batching is enabled with WORKER_BATCH_SIZE above one, the delivery is added to the batch and settled
+	* after the batch is written, so a partition prefetch a whole batch and one partition alone can fill it
+	 */
+	var batcher inf.IBatcher
+
+	if w.env.Config.WORKER.BATCH_SIZE > 1 {
+		prefetch = w.env.Config.WORKER.BATCH_SIZE
+		batcher = pkg.NewBatcher(w.ctx, w.mls, dto.BatcherOptions{
+			Size: w.env.Config.WORKER.BATCH_SIZE,
+			Wait: time.Duration(time.Millisecond * time.Duration(w.env.Config.WORKER.BATCH_WAIT)),
+		})
+	}
+
+	names := []string{cons.JOB_NAME_SEARCH}
+
+	/**
+	* Every partition queue is consumed by a single active consumer across all worker instances,
+	* so events of the same document are applied in publish order
+	 */
+	for partition := range partitions {
+		amqp_req := dto.Request[dto.BrokerOptions]{}
+
+		amqp_req.Option.ExchangeName = cons.EXCHANGE_NAME_SEARCH
+		amqp_req.Option.ExchangeType = cons.EXCHANGE_TYPE_DIRECT
+		amqp_req.Option.QueueName = fmt.Sprintf(cons.QUEUE_NAME_SEARCH_PARTITION, partition)
+		amqp_req.Option.Concurrency = 1
+		amqp_req.Option.Prefetch = prefetch
+		amqp_req.Option.Args = map[string]any{cons.X_SINGLE_ACTIVE_CONSUMER: true}
+
+		name := fmt.Sprintf(cons.JOB_NAME_SEARCH_PARTITION, partition)
+		names = append(names, name)
+
+		if err := w.jobs.Consume(broker, name, amqp_req, w.searchDelivery(broker, retry, events, idempotency, batcher, amqp_req)); err != nil {
+			pkg.Logrus(cons.ERROR, err)
+			return
+		}
+	}
+
+	/**
+	* Unpartitioned queue is kept to drain message published before partitioning, it is single active consumer
+	* like the partitions so the old message are still applied in publish order across worker instances
+	 */
+	amqp_req := dto.Request[dto.BrokerOptions]{}
 
 	amqp_req.Option.ExchangeName = cons.EXCHANGE_NAME_SEARCH
 	amqp_req.Option.ExchangeType = cons.EXCHANGE_TYPE_DIRECT
 	amqp_req.Option.QueueName = cons.QUEUE_NAME_SEARCH
+	amqp_req.Option.Concurrency = 1
 	amqp_req.Option.Prefetch = 1
-	amqp_req.Option.Args = rabbitmq.Table{cons.X_RABBIT_SECRET: w.env.Config.RABBITMQ.SECRET}
+	amqp_req.Option.Args = map[string]any{cons.X_SINGLE_ACTIVE_CONSUMER: true}
 
-	amqp.Consumer(amqp_req, func(d rabbitmq.Delivery) (action rabbitmq.Action) {
-		parser := helper.NewParser()
+	if err := w.jobs.Consume(broker, cons.JOB_NAME_SEARCH, amqp_req, w.searchDelivery(broker, retry, events, idempotency, nil, amqp_req)); err != nil {
+		pkg.Logrus(cons.ERROR, err)
+		return
+	}
 
-		dlq_req := dto.RabbitDeadLetterQueueOptions{}
-		req := dto.Request[dto.MeiliSearchDocuments[map[string]any]]{}
+	go w.searchBackpressure(names)
+}
 
-		if err := parser.Unmarshal(d.Body, &req.Body); err != nil {
-			return rabbitmq.NackDiscard
+/**
+* Search consumers are throttled while meilisearch is behind, the throttled consumers are canceled so the
+* message stay in the broker and they are started again when the task queue is drained
+ */
+func (w searchWorker) searchBackpressure(names []string) {
+	backpressure := pkg.NewBackpressure(w.ctx, w.mls, w.rds, dto.BackpressureOptions{
+		Name:     cons.BACKPRESSURE_SEARCH_WORKER,
+		High:     int64(w.env.Config.WORKER.BACKPRESSURE_HIGH),
+		Low:      int64(w.env.Config.WORKER.BACKPRESSURE_LOW),
+		Interval: time.Duration(time.Second * time.Duration(w.env.Config.WORKER.BACKPRESSURE_INTERVAL)),
+	})
+
+	for _, name := range names {
+		if err := w.jobs.Backpressure(name, backpressure); err != nil {
+			pkg.Logrus(cons.ERROR, err)
 		}
+	}
 
-		dlq_req.Body = req.Body
+	backpressure.Run(w.ctx, func(paused bool) {
+		for _, name := range names {
+			if err := w.jobs.Throttle(name, paused); err != nil {
+				pkg.Logrus(cons.ERROR, err)
+			}
+		}
+	})
+}
+
+/**
+* Version check and handler run under the consumer mutex, so the batcher receive the document
+* in the same order the version is advanced even when many message of the batch are in flight
+ */
+func (w searchWorker) searchApply(mutex *sync.Mutex, batcher inf.IBatcher, idempotency inf.IIdempotency, event *dto.Event[map[string]any], req dto.Request[dto.MeiliSearchDocuments[map[string]any]]) (<-chan error, dto.BrokerAction) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if id, version := helper.DocumentKey(req.Body.ID, req.Body.Data), w.searchEventVersion(event); id != "" && version > 0 {
+		advanced, err := idempotency.Advance(req.Body.Doc, id, version)
+		if err != nil {
+			pkg.Logrus(cons.ERROR, err)
 
-		if d.Headers[cons.X_RABBIT_SECRET] != w.env.Config.RABBITMQ.SECRET {
-			dlq_req.Secret = cons.EMPTY
-			dlq_req.Unknown = cons.TRUE
-			dlq_req.Error = errors.New("Queue is not allowed to be consumed")
+			if err := idempotency.Release(event.ID); err != nil {
+				pkg.Logrus(cons.ERROR, err)
+			}
+
+			return nil, cons.BROKER_NACK_REQUEUE
 		}
 
-		if err := w.searchChangeDataCapture(); err != nil {
-			dlq_req.Secret = d.Headers[cons.X_RABBIT_SECRET]
-			dlq_req.Unknown = cons.FALSE
-			dlq_req.Error = err
+		if !advanced {
+			w.searchDropped(idempotency, cons.IDEMPOTENCY_STALE, event)
+			return nil, cons.BROKER_ACK
 		}
+	}
 
-		if err := w.searchHandler(req); err != nil {
-			dlq_req.Secret = d.Headers[cons.X_RABBIT_SECRET]
-			dlq_req.Unknown = cons.FALSE
-			dlq_req.Error = err
+	return w.searchHandler(batcher, req), cons.BROKER_ACK
+}
+
+func (w searchWorker) searchDelivery(broker inf.IBroker, retry inf.IRetry, events inf.IEventRegistry, idempotency inf.IIdempotency, batcher inf.IBatcher, amqp_req dto.Request[dto.BrokerOptions]) func(d dto.BrokerDelivery) dto.BrokerAction {
+	envelope := helper.NewEnvelope(w.env)
+	mutex := new(sync.Mutex)
+
+	return func(d dto.BrokerDelivery) dto.BrokerAction {
+		transform := helper.NewTransform()
+
+		dlq_req := dto.RabbitDeadLetterQueueOptions{}
+		req := dto.Request[dto.MeiliSearchDocuments[map[string]any]]{}
+
+		event, err := events.Decode(d.Body)
+		if err != nil {
+			pkg.Logrus(cons.ERROR, err)
+			return cons.BROKER_NACK_DISCARD
 		}
 
-		if dlq_req.Body.Data != nil && dlq_req.Error != nil {
-			dlq_req.Exchange = amqp_req.Option.ExchangeName
-			dlq_req.ExchangeType = amqp_req.Option.ExchangeType
-			dlq_req.Queue = amqp_req.Option.QueueName
+		/**
+		* Encrypted data is only decrypted in memory, event keep the cipher text for the dead letter queue
+		 */
+		document, err := envelope.Open(event.Data)
+		if err != nil {
+			pkg.Logrus(cons.ERROR, "Event %s is not decrypted: %v", event.ID, err)
 
-			if err := w.searchDeadLetterQueue(amqp, &dlq_req); err != nil {
+			dlq_req.Body = event
+			dlq_req.Error = err
+
+			if err := w.searchDeadLetterQueue(broker, retry, d, amqp_req, &dlq_req); err != nil {
 				pkg.Logrus(cons.ERROR, err)
-				return rabbitmq.NackDiscard
+				return cons.BROKER_NACK_DISCARD
 			}
 
-			return rabbitmq.Ack
+			return cons.BROKER_ACK
 		}
 
-		return rabbitmq.Ack
-	})
+		if err := transform.SrcToDest(document, &req.Body); err != nil {
+			return cons.BROKER_NACK_DISCARD
+		}
+
+		/**
+		* Delete without deleted time is stamped with the event time, so the redelivered event write the same tombstone
+		 */
+		if req.Body.Action == cons.DELETE && helper.DeletedAt(req.Body.Data) == 0 {
+			if req.Body.Data == nil {
+				req.Body.Data = make(map[string]any)
+			}
+
+			req.Body.Data["deleted_at"] = event.Time.Unix()
+		}
+
+		claimed, err := idempotency.Claim(event.ID)
+		if err != nil {
+			pkg.Logrus(cons.ERROR, err)
+			return cons.BROKER_NACK_REQUEUE
+		}
+
+		if !claimed {
+			w.searchDropped(idempotency, cons.IDEMPOTENCY_DUPLICATE, event)
+			return cons.BROKER_ACK
+		}
+
+		res, action := w.searchApply(mutex, batcher, idempotency, event, req)
+		if res == nil {
+			return action
+		}
+
+		/**
+		* Batched delivery is settled once its batch is written, the callback return right away so the
+		* consumer keep adding the next message of the partition to the batch
+		 */
+		if batcher != nil {
+			go func() {
+				d.Settle(w.searchSettle(broker, retry, idempotency, d, amqp_req, event, req, res))
+			}()
+
+			return cons.BROKER_DEFER
+		}
+
+		return w.searchSettle(broker, retry, idempotency, d, amqp_req, event, req, res)
+	}
+}
+
+func (w searchWorker) searchSettle(broker inf.IBroker, retry inf.IRetry, idempotency inf.IIdempotency, d dto.BrokerDelivery, amqp_req dto.Request[dto.BrokerOptions], event *dto.Event[map[string]any], req dto.Request[dto.MeiliSearchDocuments[map[string]any]], res <-chan error) dto.BrokerAction {
+	dlq_req := dto.RabbitDeadLetterQueueOptions{}
+	dlq_req.Body = event
+
+	if err := w.searchChangeDataCapture(); err != nil {
+		dlq_req.Error = err
+	}
+
+	if err := <-res; err != nil {
+		dlq_req.Error = err
+	}
+
+	if dlq_req.Error != nil {
+		if err := idempotency.Release(event.ID); err != nil {
+			pkg.Logrus(cons.ERROR, err)
+		}
+	}
+
+	/**
+	* Batch interrupted by shutdown is requeued instead of dead lettered
+	 */
+	if errors.Is(dlq_req.Error, context.Canceled) {
+		return cons.BROKER_NACK_REQUEUE
+	}
+
+	if req.Body.Data != nil && dlq_req.Error != nil {
+		if err := w.searchDeadLetterQueue(broker, retry, d, amqp_req, &dlq_req); err != nil {
+			pkg.Logrus(cons.ERROR, err)
+			return cons.BROKER_NACK_DISCARD
+		}
+
+		return cons.BROKER_ACK
+	}
+
+	return cons.BROKER_ACK
 }
 
 func (w searchWorker) SearchRun() {
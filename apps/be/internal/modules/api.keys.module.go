@@ -0,0 +1,20 @@
+package module
+
+import (
+	service "github.com/restuwahyu13/go-fast-search/domain/services"
+	controller "github.com/restuwahyu13/go-fast-search/internal/adapters/http/controllers"
+	route "github.com/restuwahyu13/go-fast-search/internal/adapters/http/routes"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	usecase "github.com/restuwahyu13/go-fast-search/usecases"
+)
+
+func NewApiKeysModule[IService any](options dto.ModuleOptions) {
+	service := service.NewApiKeysService(dto.ServiceOptions{ENV: options.ENV, DB: options.DB, RDS: options.RDS, AMQP: options.AMQP, MLS: options.MLS})
+
+	usecase := usecase.NewApiKeysUsecase(dto.UsecaseOptions[inf.IApiKeysService]{SERVICE: service})
+
+	controller := controller.NewApiKeysController(dto.ControllerOptions[inf.IApiKeysUsecase]{USECASE: usecase})
+
+	route.NewApiKeysRoute(dto.RouteOptions[inf.IApiKeysController]{ENV: options.ENV, RDS: options.RDS, ROUTER: options.ROUTER, CONTROLLER: controller})
+}
@@ -0,0 +1,20 @@
+package module
+
+import (
+	service "github.com/restuwahyu13/go-fast-search/domain/services"
+	controller "github.com/restuwahyu13/go-fast-search/internal/adapters/http/controllers"
+	route "github.com/restuwahyu13/go-fast-search/internal/adapters/http/routes"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	usecase "github.com/restuwahyu13/go-fast-search/usecases"
+)
+
+func NewAuditLogsModule[IService any](options dto.ModuleOptions) {
+	service := service.NewAuditLogsService(dto.ServiceOptions{ENV: options.ENV, DB: options.DB})
+
+	usecase := usecase.NewAuditLogsUsecase(dto.UsecaseOptions[inf.IAuditLogsService]{SERVICE: service})
+
+	controller := controller.NewAuditLogsController(dto.ControllerOptions[inf.IAuditLogsUsecase]{USECASE: usecase})
+
+	route.NewAuditLogsRoute(dto.RouteOptions[inf.IAuditLogsController]{ENV: options.ENV, RDS: options.RDS, ROUTER: options.ROUTER, CONTROLLER: controller})
+}
@@ -0,0 +1,20 @@
+package module
+
+import (
+	service "github.com/restuwahyu13/go-fast-search/domain/services"
+	controller "github.com/restuwahyu13/go-fast-search/internal/adapters/http/controllers"
+	route "github.com/restuwahyu13/go-fast-search/internal/adapters/http/routes"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	usecase "github.com/restuwahyu13/go-fast-search/usecases"
+)
+
+func NewDeadLettersModule[IService any](options dto.ModuleOptions) {
+	service := service.NewDeadLettersService(dto.ServiceOptions{ENV: options.ENV, DB: options.DB, RDS: options.RDS, AMQP: options.AMQP})
+
+	usecase := usecase.NewDeadLettersUsecase(dto.UsecaseOptions[inf.IDeadLettersService]{SERVICE: service})
+
+	controller := controller.NewDeadLettersController(dto.ControllerOptions[inf.IDeadLettersUsecase]{USECASE: usecase})
+
+	route.NewDeadLettersRoute(dto.RouteOptions[inf.IDeadLettersController]{ENV: options.ENV, RDS: options.RDS, ROUTER: options.ROUTER, CONTROLLER: controller})
+}
@@ -0,0 +1,20 @@
+package module
+
+import (
+	service "github.com/restuwahyu13/go-fast-search/domain/services"
+	controller "github.com/restuwahyu13/go-fast-search/internal/adapters/http/controllers"
+	route "github.com/restuwahyu13/go-fast-search/internal/adapters/http/routes"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	usecase "github.com/restuwahyu13/go-fast-search/usecases"
+)
+
+func NewJobsModule[IService any](options dto.RegistryModuleOptions[inf.IJobControl]) {
+	service := service.NewJobsService(dto.RegistryServiceOptions[inf.IJobControl]{REGISTRY: options.REGISTRY})
+
+	usecase := usecase.NewJobsUsecase(dto.UsecaseOptions[inf.IJobsService]{SERVICE: service})
+
+	controller := controller.NewJobsController(dto.ControllerOptions[inf.IJobsUsecase]{USECASE: usecase})
+
+	route.NewJobsRoute(dto.RouteOptions[inf.IJobsController]{ENV: options.ENV, RDS: options.RDS, ROUTER: options.ROUTER, CONTROLLER: controller})
+}
@@ -0,0 +1,20 @@
+package module
+
+import (
+	service "github.com/restuwahyu13/go-fast-search/domain/services"
+	controller "github.com/restuwahyu13/go-fast-search/internal/adapters/http/controllers"
+	route "github.com/restuwahyu13/go-fast-search/internal/adapters/http/routes"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	usecase "github.com/restuwahyu13/go-fast-search/usecases"
+)
+
+func NewLeasesModule[IService any](options dto.ModuleOptions) {
+	service := service.NewLeasesService(dto.ServiceOptions{ENV: options.ENV, DB: options.DB, RDS: options.RDS, AMQP: options.AMQP, MLS: options.MLS})
+
+	usecase := usecase.NewLeasesUsecase(dto.UsecaseOptions[inf.ILeasesService]{SERVICE: service})
+
+	controller := controller.NewLeasesController(dto.ControllerOptions[inf.ILeasesUsecase]{USECASE: usecase})
+
+	route.NewLeasesRoute(dto.RouteOptions[inf.ILeasesController]{ENV: options.ENV, RDS: options.RDS, ROUTER: options.ROUTER, CONTROLLER: controller})
+}
@@ -16,5 +16,5 @@ func NewUsersModule[IService any](options dto.ModuleOptions) {
 
 	controller := controller.NewUsersController(dto.ControllerOptions[inf.IUsersUsecase]{USECASE: usecase})
 
-	route.NewUsersRoute(dto.RouteOptions[inf.IUsersController]{ROUTER: options.ROUTER, CONTROLLER: controller})
+	route.NewUsersRoute(dto.RouteOptions[inf.IUsersController]{ENV: options.ENV, DB: options.DB, RDS: options.RDS, ROUTER: options.ROUTER, CONTROLLER: controller})
 }
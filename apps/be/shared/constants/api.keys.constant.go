@@ -0,0 +1,13 @@
+package cons
+
+const (
+	API_KEY_HEADER         = "X-API-Key"
+	API_KEY_SCHEME         = "fsk"
+	API_KEY_PREFIX_LENGTH  = 6
+	API_KEY_SECRET_LENGTH  = 32
+	API_KEY_TOUCH_INTERVAL = 60
+)
+
+const (
+	AUTH_API_KEY_ID = "api_key_id"
+)
@@ -0,0 +1,21 @@
+package cons
+
+const (
+	AUDIT_ACTION_CREATE = "create"
+	AUDIT_ACTION_UPDATE = "update"
+	AUDIT_ACTION_DELETE = "delete"
+	AUDIT_ACTION_SEARCH = "search"
+)
+
+const (
+	AUDIT_ACTOR_USER      = "user"
+	AUDIT_ACTOR_API_KEY   = "api_key"
+	AUDIT_ACTOR_SYSTEM    = "system"
+	AUDIT_ACTOR_ANONYMOUS = "anonymous"
+)
+
+const (
+	AUDIT_TARGET_USERS    = "users"
+	AUDIT_IP_ADDRESS      = "ip_address"
+	AUDIT_SCHEDULER_ACTOR = "scheduler"
+)
@@ -0,0 +1,50 @@
+package cons
+
+import "errors"
+
+var (
+	AUTH_TOKEN_INVALID error = errors.New("auth: token is invalid")
+	AUTH_TOKEN_REVOKED error = errors.New("auth: token is revoked")
+	AUTH_TOKEN_REUSED  error = errors.New("auth: refresh token is reused")
+)
+
+const (
+	AUTH_ACCESS_PREFIX        = "%s:%s"
+	AUTH_REFRESH_PREFIX       = "REFRESH:%s:%s:%s"
+	AUTH_SESSION_KEY          = "AUTH:SESSION:%s"
+	AUTH_SUBJECT_SESSIONS_KEY = "AUTH:SUBJECT:%s:SESSIONS"
+	AUTH_REVOKED_KEY          = "AUTH:REVOKED:%s"
+	AUTH_REFRESH_EXPIRED      = 10080
+)
+
+const (
+	AUTH_USER_ID    = "user_id"
+	AUTH_SESSION_ID = "session_id"
+	AUTH_TENANT_ID  = "tenant_id"
+)
+
+const (
+	AUTH_MODE_LOCAL = "local"
+	AUTH_MODE_OIDC  = "oidc"
+	AUTH_MODE_BOTH  = "both"
+)
+
+const (
+	AUTH_ROLE        = "role"
+	AUTH_PERMISSIONS = "permissions"
+	AUTH_PUBLIC      = "public"
+)
+
+const (
+	ROLE_ADMIN   = "admin"
+	ROLE_SUPPORT = "support"
+)
+
+const (
+	PERMISSION_USERS_READ   = "users:read"
+	PERMISSION_USERS_WRITE  = "users:write"
+	PERMISSION_USERS_DELETE = "users:delete"
+	PERMISSION_SEARCH_ADMIN = "search:admin"
+	PERMISSION_KEYS_ADMIN   = "keys:admin"
+	PERMISSION_AUDIT_READ   = "audit:read"
+)
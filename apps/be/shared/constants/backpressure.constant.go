@@ -0,0 +1,19 @@
+package cons
+
+const (
+	BACKPRESSURE_METRICS_KEY = "BACKPRESSURE:%s:METRICS"
+)
+
+const (
+	BACKPRESSURE_PAUSE  = "pause"
+	BACKPRESSURE_RESUME = "resume"
+)
+
+const (
+	BACKPRESSURE_HIGH     = 1000
+	BACKPRESSURE_INTERVAL = 5
+)
+
+const (
+	BACKPRESSURE_SEARCH_WORKER = "WORKER:SEARCH"
+)
@@ -0,0 +1,32 @@
+package cons
+
+import "errors"
+
+var (
+	BROKER_UNKNOWN_DRIVER error = errors.New("broker: driver is not supported")
+	BROKER_NOT_CONNECTED  error = errors.New("broker: connection is not created for the configured driver")
+)
+
+const (
+	BROKER_DRIVER_RABBITMQ = "rabbitmq"
+	BROKER_DRIVER_REDIS    = "redis"
+	BROKER_DRIVER_MEMORY   = "memory"
+)
+
+/**
+* BROKER_DEFER leave the delivery unsettled when the callback return, the callback own the delivery
+* and call its Settle exactly once with the final action
+ */
+const (
+	BROKER_ACK = iota
+	BROKER_NACK_DISCARD
+	BROKER_NACK_REQUEUE
+	BROKER_DEFER
+)
+
+const (
+	BROKER_STREAM_KEY     = "BROKER:STREAM:%s"
+	BROKER_DELAYED_KEY    = "BROKER:DELAYED"
+	BROKER_CONSUMER_LEASE = "broker.%s"
+	BROKER_MEMORY_BUFFER  = 1024
+)
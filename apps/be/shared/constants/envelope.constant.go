@@ -0,0 +1,12 @@
+package cons
+
+import "errors"
+
+var (
+	ENVELOPE_UNKNOWN_KEY error = errors.New("envelope: encryption key id is unknown")
+	ENVELOPE_INVALID     error = errors.New("envelope: encrypted data is invalid")
+)
+
+const (
+	REDACTED = "[REDACTED]"
+)
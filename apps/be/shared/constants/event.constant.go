@@ -0,0 +1,25 @@
+package cons
+
+import "errors"
+
+var (
+	EVENT_UNKNOWN_TYPE        error = errors.New("event: event type is not registered")
+	EVENT_UNSUPPORTED_VERSION error = errors.New("event: event version is not supported")
+	EVENT_INVALID             error = errors.New("event: event payload is invalid")
+)
+
+const (
+	EVENT_SPEC_VERSION  = "1.0"
+	EVENT_SOURCE_API    = "go-fast-search/api"
+	EVENT_SOURCE_LEGACY = "go-fast-search/legacy"
+)
+
+const (
+	EVENT_TYPE_SEARCH_DOCUMENT    = "search.document"
+	EVENT_VERSION_SEARCH_DOCUMENT = 1
+)
+
+const (
+	EVENT_TYPE_AUDIT_LOG    = "audit.log"
+	EVENT_VERSION_AUDIT_LOG = 1
+)
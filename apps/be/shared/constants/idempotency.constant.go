@@ -0,0 +1,16 @@
+package cons
+
+const (
+	IDEMPOTENCY_EVENT_KEY   = "IDEMPOTENCY:%s:EVENT:%s"
+	IDEMPOTENCY_VERSION_KEY = "IDEMPOTENCY:%s:VERSION:%s:%s"
+	IDEMPOTENCY_METRICS_KEY = "IDEMPOTENCY:%s:METRICS"
+)
+
+const (
+	IDEMPOTENCY_DUPLICATE = "duplicate"
+	IDEMPOTENCY_STALE     = "stale"
+)
+
+const (
+	IDEMPOTENCY_SEARCH_WORKER = "WORKER:SEARCH"
+)
@@ -0,0 +1,17 @@
+package cons
+
+import "errors"
+
+var (
+	KEYSET_KEY_NOT_FOUND error = errors.New("keyset: signing key is not found or retired")
+)
+
+const (
+	KEYSET_KEYS_KEY     = "AUTH:KEYSET:KEYS"
+	KEYSET_ACTIVE_KEY   = "AUTH:KEYSET:ACTIVE"
+	KEYSET_RETIRED_KEY  = "AUTH:KEYSET:RETIRED"
+	KEYSET_KEY_USAGE    = "sig"
+	KEYSET_ROTATION     = 43200
+	KEYSET_JWKS_PATH    = "/.well-known/jwks.json"
+	KEYSET_JWKS_MAX_AGE = 300
+)
@@ -0,0 +1,20 @@
+package cons
+
+import "errors"
+
+var (
+	LEASE_NOT_HELD error = errors.New("lease: lease is no longer held by this holder")
+)
+
+const (
+	LEASE_KEY             = "LEASE:%s"
+	LEASE_TOKEN_KEY       = "LEASE:%s:TOKEN"
+	LEASE_FENCE_KEY       = "LEASE:%s:FENCE"
+	LEASE_REGISTRY_KEY    = "LEASE:REGISTRY"
+	LEASE_RELEASE_TIMEOUT = 5
+)
+
+const (
+	LEASE_SEARCH_SCHEDULER      = "SCHEDULER:SEARCH"
+	LEASE_SIGNING_KEY_SCHEDULER = "SCHEDULER:SIGNING_KEY"
+)
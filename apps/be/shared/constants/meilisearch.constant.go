@@ -0,0 +1,20 @@
+package cons
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	MEILISEARCH_UNKNOWN_ACTION error = errors.New("Meilisearch unknown action")
+	MEILISEARCH_MISSING_ID     error = errors.New("meilisearch: document id is required")
+	MEILISEARCH_TASK_FAILED    error = errors.New("meilisearch: task is not succeeded")
+	MEILISEARCH_MISSING_DELETE error = errors.New("meilisearch: deleted time is required to delete document")
+)
+
+const (
+	MEILISEARCH_TASK_TIMEOUT = time.Duration(time.Minute)
+	MEILISEARCH_BATCH_SIZE   = 100
+	MEILISEARCH_BATCH_WAIT   = 200
+	MEILISEARCH_BATCH_QUEUE  = 64
+)
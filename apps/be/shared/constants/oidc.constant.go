@@ -0,0 +1,18 @@
+package cons
+
+import "errors"
+
+var (
+	OIDC_NOT_CONFIGURED error = errors.New("oidc: jwks url, issuer and audience is required")
+	OIDC_KEY_NOT_FOUND  error = errors.New("oidc: signing key is not found in jwks")
+)
+
+const (
+	OIDC_JWKS_REFRESH     = 900
+	OIDC_JWKS_MIN_REFRESH = 10
+	OIDC_JWKS_TIMEOUT     = 10
+	OIDC_JWKS_MAX_SIZE    = 1 << 20
+	OIDC_LEEWAY           = 30
+	OIDC_ROLE_CLAIM       = "roles"
+	OIDC_TENANT_CLAIM     = "tenant_id"
+)
@@ -1,12 +1,24 @@
 package cons
 
+import "errors"
+
+var (
+	PUBLISHER_NOT_FOUND     error = errors.New("rabbitmq: publisher is not created for this connection")
+	PUBLISH_NACKED          error = errors.New("rabbitmq: message is rejected by broker")
+	PUBLISH_CONFIRM_TIMEOUT error = errors.New("rabbitmq: message confirmation is timed out")
+)
+
 const (
-	X_RABBIT_SECRET        = "x-rabbit-secret"
-	X_RABBIT_UNKNOWN       = "x-rabbit-unknown"
-	X_RABBIT_QUEUE         = "x-rabbit-queue"
-	X_RABBIT_EXCHANGE      = "x-rabbit-exchange"
-	X_RABBIT_EXCHANGE_TYPE = "x-rabbit-exchange-type"
-	X_MESSAGE_TTL          = "x-message-ttl"
+	X_RABBIT_QUEUE           = "x-rabbit-queue"
+	X_RABBIT_EXCHANGE        = "x-rabbit-exchange"
+	X_RABBIT_EXCHANGE_TYPE   = "x-rabbit-exchange-type"
+	X_MESSAGE_TTL            = "x-message-ttl"
+	X_RETRY_ATTEMPT          = "x-retry-attempt"
+	X_RETRY_ERROR            = "x-retry-error"
+	X_DEAD_LETTER_EXCHANGE   = "x-dead-letter-exchange"
+	X_DEAD_LETTER_ROUTING    = "x-dead-letter-routing-key"
+	X_DEAD_LETTER_ID         = "x-dead-letter-id"
+	X_SINGLE_ACTIVE_CONSUMER = "x-single-active-consumer"
 )
 
 const (
@@ -21,9 +33,27 @@ const (
 	EXCHANGE_NAME_TOPIC             = "amq.topic"
 	EXCHANGE_NAME_SEARCH            = "amqp.worker"
 	EXCHANGE_NAME_DEAD_LETTER_QUEUE = "amqp.worker.dlq"
+	EXCHANGE_NAME_RETRY             = "amqp.worker.retry"
+	EXCHANGE_NAME_PARKING           = "amqp.worker.parking"
+	EXCHANGE_NAME_AUDIT             = "amqp.worker.audit"
 )
 
 const (
 	QUEUE_NAME_SEARCH            = "worker.search"
+	QUEUE_NAME_SEARCH_PARTITION  = "worker.search.%d"
 	QUEUE_NAME_DEAD_LETTER_QUEUE = "worker.dlq"
+	QUEUE_NAME_PARKING           = "worker.parking"
+	QUEUE_NAME_RETRY             = "%s.retry.%d"
+	QUEUE_NAME_AUDIT             = "worker.audit"
+)
+
+const (
+	QUEUE_PARTITIONS = 4
+)
+
+const (
+	DEAD_LETTER_STATUS_RETRYING  = "retrying"
+	DEAD_LETTER_STATUS_PARKED    = "parked"
+	DEAD_LETTER_STATUS_REPLAYED  = "replayed"
+	DEAD_LETTER_STATUS_DISCARDED = "discarded"
 )
@@ -0,0 +1,8 @@
+package cons
+
+const (
+	RATE_LIMIT_KEY           = "RATE_LIMIT:%s:%s:%s"
+	RATE_LIMIT_IDENTITY_KEY  = "key"
+	RATE_LIMIT_IDENTITY_USER = "user"
+	RATE_LIMIT_IDENTITY_IP   = "ip"
+)
@@ -0,0 +1,64 @@
+package cons
+
+import "errors"
+
+var (
+	JOB_NOT_FOUND        error = errors.New("job: job is not registered")
+	JOB_STANDBY          error = errors.New("job: lease is held by another instance")
+	JOB_ALREADY_RUNNING  error = errors.New("job: previous run is still in progress")
+	JOB_NOT_TRIGGERABLE  error = errors.New("job: job cannot be triggered manually")
+	JOB_SHUTDOWN         error = errors.New("job: registry is shutting down")
+	JOB_SHUTDOWN_TIMEOUT error = errors.New("job: shutdown deadline is exceeded")
+)
+
+const (
+	JOB_SHUTDOWN_DEFAULT_TIMEOUT = 30
+	JOB_PAUSED_KEY               = "SCHEDULER:PAUSED"
+)
+
+const (
+	DELETE_MODE_TOMBSTONE = "tombstone"
+	DELETE_MODE_HARD      = "hard"
+)
+
+const (
+	OVERLAP_SKIP  = "skip"
+	OVERLAP_WAIT  = "wait"
+	OVERLAP_ALLOW = "allow"
+)
+
+const (
+	JOB_STATUS_RUNNING = "running"
+	JOB_STATUS_SUCCESS = "success"
+	JOB_STATUS_FAILED  = "failed"
+	JOB_STATUS_TIMEOUT = "timeout"
+	JOB_STATUS_SKIPPED = "skipped"
+)
+
+const (
+	JOB_NAME_SEARCH           = "search"
+	JOB_NAME_SEARCH_PARTITION = "search.%d"
+)
+
+const (
+	JOB_NAME_DEAD_LETTER_QUEUE = "dead_letter_queue"
+)
+
+const (
+	JOB_NAME_SIGNING_KEY = "signing_key"
+)
+
+const (
+	JOB_NAME_AUDIT = "audit"
+)
+
+const (
+	JOB_KIND_SCHEDULER = "scheduler"
+	JOB_KIND_CONSUMER  = "consumer"
+)
+
+const (
+	JOB_TRIGGER_SCHEDULE = "schedule"
+	JOB_TRIGGER_MANUAL   = "manual"
+	JOB_TRIGGER_DELIVERY = "delivery"
+)
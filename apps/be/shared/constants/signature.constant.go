@@ -0,0 +1,22 @@
+package cons
+
+import "errors"
+
+var (
+	SIGNATURE_MISSING     error = errors.New("signature: message is not signed")
+	SIGNATURE_UNKNOWN_KEY error = errors.New("signature: signing key id is unknown")
+	SIGNATURE_INVALID     error = errors.New("signature: message signature is invalid")
+	SIGNATURE_EXPIRED     error = errors.New("signature: message signature is expired")
+)
+
+const (
+	X_SIGNATURE           = "x-signature"
+	X_SIGNATURE_KEY_ID    = "x-signature-key-id"
+	X_SIGNATURE_TIMESTAMP = "x-signature-timestamp"
+	X_SIGNATURE_EXPIRES   = "x-signature-expires"
+)
+
+const (
+	SIGNATURE_DEFAULT_KEY_ID = "default"
+	SIGNATURE_DEFAULT_TTL    = 86400
+)
@@ -29,6 +29,7 @@ type (
 
 	RouteOptions[T any] struct {
 		ENV        Request[Environtment]
+		DB         *bun.DB
 		RDS        *redis.Client
 		ROUTER     chi.Router
 		CONTROLLER T
@@ -60,6 +61,17 @@ type (
 		MLS    meilisearch.ServiceManager
 		ROUTER chi.Router
 	}
+
+	RegistryServiceOptions[T any] struct {
+		REGISTRY T
+	}
+
+	RegistryModuleOptions[T any] struct {
+		ENV      Request[Environtment]
+		RDS      *redis.Client
+		REGISTRY T
+		ROUTER   chi.Router
+	}
 )
 
 type (
@@ -3,18 +3,62 @@ package dto
 import opt "github.com/restuwahyu13/go-fast-search/shared/output"
 
 type Config struct {
-	ENV                 string `env:"GO_ENV" mapstructure:"GO_ENV"`
-	PORT                string `env:"PORT" mapstructure:"PORT"`
-	INBOUND_SIZE        int    `env:"INBOUND_SIZE" mapstructure:"INBOUND_SIZE"`
-	PG_DSN              string `env:"PG_DSN" mapstructure:"PG_DSN"`
-	REDIS_CSN           string `env:"REDIS_CSN" mapstructure:"REDIS_CSN"`
-	JWT_SECRET_KEY      string `env:"JWT_SECRET_KEY" mapstructure:"JWT_SECRET_KEY"`
-	JWT_EXPIRED         int    `env:"JWT_EXPIRED" mapstructure:"JWT_EXPIRED"`
-	RABBITMQ_QSN        string `env:"RABBITMQ_QSN" mapstructure:"RABBITMQ_QSN"`
-	RABBITMQ_VSN        string `env:"RABBITMQ_VSN" mapstructure:"RABBITMQ_VSN"`
-	RABBITMQ_SECRET_KEY string `env:"RABBITMQ_SECRET_KEY" mapstructure:"RABBITMQ_SECRET_KEY"`
-	MEILI_DSN           string `env:"MEILI_DSN" mapstructure:"MEILI_DSN"`
-	MEILI_MASTER_KEY    string `env:"MEILI_MASTER_KEY" mapstructure:"MEILI_MASTER_KEY"`
+	ENV                          string `env:"GO_ENV" mapstructure:"GO_ENV"`
+	PORT                         string `env:"PORT" mapstructure:"PORT"`
+	INBOUND_SIZE                 int    `env:"INBOUND_SIZE" mapstructure:"INBOUND_SIZE"`
+	TRUSTED_PROXIES              string `env:"TRUSTED_PROXIES" mapstructure:"TRUSTED_PROXIES"`
+	PG_DSN                       string `env:"PG_DSN" mapstructure:"PG_DSN"`
+	REDIS_CSN                    string `env:"REDIS_CSN" mapstructure:"REDIS_CSN"`
+	JWT_SECRET_KEY               string `env:"JWT_SECRET_KEY" mapstructure:"JWT_SECRET_KEY"`
+	JWT_EXPIRED                  int    `env:"JWT_EXPIRED" mapstructure:"JWT_EXPIRED"`
+	JWT_REFRESH_EXPIRED          int    `env:"JWT_REFRESH_EXPIRED" mapstructure:"JWT_REFRESH_EXPIRED"`
+	JWT_KEY_ROTATION             int    `env:"JWT_KEY_ROTATION" mapstructure:"JWT_KEY_ROTATION"`
+	JWT_KEY_GRACE                int    `env:"JWT_KEY_GRACE" mapstructure:"JWT_KEY_GRACE"`
+	AUTH_PUBLIC_ROUTES           string `env:"AUTH_PUBLIC_ROUTES" mapstructure:"AUTH_PUBLIC_ROUTES"`
+	AUTH_MODE                    string `env:"AUTH_MODE" mapstructure:"AUTH_MODE"`
+	OIDC_JWKS_URL                string `env:"OIDC_JWKS_URL" mapstructure:"OIDC_JWKS_URL"`
+	OIDC_JWKS_REFRESH            int    `env:"OIDC_JWKS_REFRESH" mapstructure:"OIDC_JWKS_REFRESH"`
+	OIDC_ISSUER                  string `env:"OIDC_ISSUER" mapstructure:"OIDC_ISSUER"`
+	OIDC_AUDIENCE                string `env:"OIDC_AUDIENCE" mapstructure:"OIDC_AUDIENCE"`
+	OIDC_LEEWAY                  int    `env:"OIDC_LEEWAY" mapstructure:"OIDC_LEEWAY"`
+	OIDC_ROLE_CLAIM              string `env:"OIDC_ROLE_CLAIM" mapstructure:"OIDC_ROLE_CLAIM"`
+	OIDC_ROLES                   string `env:"OIDC_ROLES" mapstructure:"OIDC_ROLES"`
+	OIDC_TENANT_CLAIM            string `env:"OIDC_TENANT_CLAIM" mapstructure:"OIDC_TENANT_CLAIM"`
+	RATE_LIMIT_RULES             string `env:"RATE_LIMIT_RULES" mapstructure:"RATE_LIMIT_RULES"`
+	AUDIT_HASH_KEY               string `env:"AUDIT_HASH_KEY" mapstructure:"AUDIT_HASH_KEY"`
+	BROKER_DRIVER                string `env:"BROKER_DRIVER" mapstructure:"BROKER_DRIVER"`
+	BROKER_SIGNING_KEY_ID        string `env:"BROKER_SIGNING_KEY_ID" mapstructure:"BROKER_SIGNING_KEY_ID"`
+	BROKER_SIGNING_KEYS          string `env:"BROKER_SIGNING_KEYS" mapstructure:"BROKER_SIGNING_KEYS"`
+	BROKER_SIGNATURE_TTL         int    `env:"BROKER_SIGNATURE_TTL" mapstructure:"BROKER_SIGNATURE_TTL"`
+	BROKER_ENCRYPTION_KEY_ID     string `env:"BROKER_ENCRYPTION_KEY_ID" mapstructure:"BROKER_ENCRYPTION_KEY_ID"`
+	BROKER_ENCRYPTION_KEYS       string `env:"BROKER_ENCRYPTION_KEYS" mapstructure:"BROKER_ENCRYPTION_KEYS"`
+	RABBITMQ_QSN                 string `env:"RABBITMQ_QSN" mapstructure:"RABBITMQ_QSN"`
+	RABBITMQ_VSN                 string `env:"RABBITMQ_VSN" mapstructure:"RABBITMQ_VSN"`
+	RABBITMQ_SECRET_KEY          string `env:"RABBITMQ_SECRET_KEY" mapstructure:"RABBITMQ_SECRET_KEY"`
+	RABBITMQ_RETRY_MAX           int    `env:"RABBITMQ_RETRY_MAX" mapstructure:"RABBITMQ_RETRY_MAX"`
+	RABBITMQ_RETRY_DELAY         int    `env:"RABBITMQ_RETRY_DELAY" mapstructure:"RABBITMQ_RETRY_DELAY"`
+	RABBITMQ_POOL_SIZE           int    `env:"RABBITMQ_POOL_SIZE" mapstructure:"RABBITMQ_POOL_SIZE"`
+	RABBITMQ_CONFIRM_TTL         int    `env:"RABBITMQ_CONFIRM_TTL" mapstructure:"RABBITMQ_CONFIRM_TTL"`
+	RABBITMQ_PARTITIONS          int    `env:"RABBITMQ_PARTITIONS" mapstructure:"RABBITMQ_PARTITIONS"`
+	RABBITMQ_TLS_SKIP_VERIFY     bool   `env:"RABBITMQ_TLS_SKIP_VERIFY" mapstructure:"RABBITMQ_TLS_SKIP_VERIFY"`
+	MEILI_DSN                    string `env:"MEILI_DSN" mapstructure:"MEILI_DSN"`
+	MEILI_MASTER_KEY             string `env:"MEILI_MASTER_KEY" mapstructure:"MEILI_MASTER_KEY"`
+	SCHEDULER_LEASE_TTL          int    `env:"SCHEDULER_LEASE_TTL" mapstructure:"SCHEDULER_LEASE_TTL"`
+	SCHEDULER_DELETE_MODE        string `env:"SCHEDULER_DELETE_MODE" mapstructure:"SCHEDULER_DELETE_MODE"`
+	SCHEDULER_CRONTAB            string `env:"SCHEDULER_CRONTAB" mapstructure:"SCHEDULER_CRONTAB"`
+	SCHEDULER_PORT               string `env:"SCHEDULER_PORT" mapstructure:"SCHEDULER_PORT"`
+	SCHEDULER_BATCH_SIZE         int    `env:"SCHEDULER_BATCH_SIZE" mapstructure:"SCHEDULER_BATCH_SIZE"`
+	SCHEDULER_SHUTDOWN_TIMEOUT   int    `env:"SCHEDULER_SHUTDOWN_TIMEOUT" mapstructure:"SCHEDULER_SHUTDOWN_TIMEOUT"`
+	WORKER_PORT                  string `env:"WORKER_PORT" mapstructure:"WORKER_PORT"`
+	WORKER_DEDUP_TTL             int    `env:"WORKER_DEDUP_TTL" mapstructure:"WORKER_DEDUP_TTL"`
+	WORKER_VERSION_TTL           int    `env:"WORKER_VERSION_TTL" mapstructure:"WORKER_VERSION_TTL"`
+	WORKER_BATCH_SIZE            int    `env:"WORKER_BATCH_SIZE" mapstructure:"WORKER_BATCH_SIZE"`
+	WORKER_BATCH_WAIT            int    `env:"WORKER_BATCH_WAIT" mapstructure:"WORKER_BATCH_WAIT"`
+	WORKER_BACKPRESSURE_HIGH     int    `env:"WORKER_BACKPRESSURE_HIGH" mapstructure:"WORKER_BACKPRESSURE_HIGH"`
+	WORKER_BACKPRESSURE_LOW      int    `env:"WORKER_BACKPRESSURE_LOW" mapstructure:"WORKER_BACKPRESSURE_LOW"`
+	WORKER_BACKPRESSURE_INTERVAL int    `env:"WORKER_BACKPRESSURE_INTERVAL" mapstructure:"WORKER_BACKPRESSURE_INTERVAL"`
+	WORKER_CONSUMERS             int    `env:"WORKER_CONSUMERS" mapstructure:"WORKER_CONSUMERS"`
+	WORKER_SHUTDOWN_TIMEOUT      int    `env:"WORKER_SHUTDOWN_TIMEOUT" mapstructure:"WORKER_SHUTDOWN_TIMEOUT"`
 }
 
 type (
@@ -23,7 +67,14 @@ type (
 		REDIS       opt.Redis
 		POSTGRES    opt.Postgres
 		JWT         opt.Jwt
+		AUTH        opt.Auth
+		OIDC        opt.Oidc
+		RATE_LIMIT  opt.RateLimit
+		AUDIT       opt.Audit
+		BROKER      opt.Broker
 		RABBITMQ    opt.RabbitMQ
 		MEILISEARCH opt.MeiliSearch
+		SCHEDULER   opt.Scheduler
+		WORKER      opt.Worker
 	}
 )
@@ -0,0 +1,8 @@
+package dto
+
+type (
+	EnvelopeKey struct {
+		KeyID string `json:"keyid" validate:"required"`
+		Key   string `json:"key" validate:"required"`
+	}
+)
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+type (
+	BackpressureOptions struct {
+		Name     string
+		High     int64
+		Low      int64
+		Interval time.Duration
+	}
+)
@@ -0,0 +1,10 @@
+package dto
+
+import "time"
+
+type (
+	BatcherOptions struct {
+		Size int
+		Wait time.Duration
+	}
+)
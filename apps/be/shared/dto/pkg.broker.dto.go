@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+type (
+	BrokerAction int
+
+	BrokerOptions struct {
+		ExchangeName string
+		ExchangeType string
+		QueueName    string
+		Ack          bool
+		Concurrency  int
+		ConsumerID   string
+		Args         map[string]any
+		Body         any
+		ContentType  string
+		Timestamp    time.Time
+		Expiration   string
+		Prefetch     int
+	}
+
+	BrokerDelivery struct {
+		ID      string
+		Headers map[string]any
+		Body    []byte
+		Settle  func(action BrokerAction)
+	}
+)
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+type (
+	Event[T any] struct {
+		ID            string    `json:"id" validate:"required"`
+		Type          string    `json:"type" validate:"required"`
+		Source        string    `json:"source" validate:"required"`
+		SpecVersion   string    `json:"specversion" validate:"required"`
+		DataVersion   int       `json:"dataversion" validate:"min=0"`
+		CorrelationID string    `json:"correlationid,omitempty"`
+		Time          time.Time `json:"time"`
+		Data          T         `json:"data"`
+	}
+
+	EventSchema struct {
+		Type      string
+		Version   int
+		Legacy    bool
+		Upcasters map[int]func(data map[string]any) (map[string]any, error)
+		Validate  func(data map[string]any) error
+	}
+)
@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+type (
+	IdempotencyOptions struct {
+		Name       string
+		TTL        time.Duration
+		VersionTTL time.Duration
+	}
+)
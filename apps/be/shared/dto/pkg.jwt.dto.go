@@ -11,6 +11,7 @@ type (
 	JwtSignOption struct {
 		PrivateKey *rsa.PrivateKey
 		Claim      interface{}
+		Claims     map[string]any
 		Kid        string
 		SecretKey  string
 		Iss        string
@@ -39,4 +40,10 @@ type (
 		Token   string `json:"token"`
 		Expired int    `json:"expired"`
 	}
+
+	SigningKeyMetadata struct {
+		Kid        string    `json:"kid"`
+		PrivKeyRaw string    `json:"privKeyRaw"`
+		CreatedAt  time.Time `json:"createdAt"`
+	}
 )
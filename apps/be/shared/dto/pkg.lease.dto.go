@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+type (
+	LeaseOptions struct {
+		Name     string
+		Holder   string
+		TTL      time.Duration
+		Interval time.Duration
+	}
+)
@@ -2,11 +2,12 @@ package dto
 
 type (
 	MeiliSearchDocuments[T any] struct {
-		ID     any    `json:"id"`
-		Doc    string `json:"doc"`
-		Data   T      `json:"data"`
-		IsBulk bool   `json:"is_bulk"`
-		Action string `json:"action"`
+		ID         any          `json:"id" validate:"required_unless=Action insert"`
+		Doc        string       `json:"doc" validate:"required"`
+		Data       T            `json:"data"`
+		IsBulk     bool         `json:"is_bulk"`
+		Action     string       `json:"action" validate:"required,oneof=insert update delete"`
+		Encryption *EnvelopeKey `json:"encryption,omitempty" validate:"omitempty"`
 	}
 
 	MeiliSearchDocumentsQuery struct {
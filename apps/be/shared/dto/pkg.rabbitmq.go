@@ -1,33 +1,31 @@
 package dto
 
-import (
-	"time"
-
-	"github.com/wagslane/go-rabbitmq"
-)
+import "time"
 
 type (
-	RabbitOptions struct {
-		ExchangeName string
-		ExchangeType string
-		QueueName    string
-		Ack          bool
-		Concurrency  int
-		ConsumerID   string
-		Args         rabbitmq.Table
-		Body         any
-		ContentType  string
-		Timestamp    time.Time
-		Prefetch     int
-	}
-
 	RabbitDeadLetterQueueOptions struct {
 		Exchange     string
 		ExchangeType string
 		Queue        string
-		Body         MeiliSearchDocuments[map[string]any]
-		Secret       any
-		Unknown      bool
+		Body         any
+		Attempt      int
+		DeadLetterID any
 		Error        error
 	}
+
+	RabbitExchangeOptions struct {
+		Name string
+		Kind string
+	}
+
+	PublisherOptions struct {
+		Size      int
+		Timeout   time.Duration
+		Exchanges []RabbitExchangeOptions
+	}
+
+	RetryOptions struct {
+		Max   int
+		Delay time.Duration
+	}
 )
@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	Job struct {
+		Name    string
+		Crontab string
+		Timeout time.Duration
+		Overlap string
+		Locks   []string
+		Handler func(ctx context.Context, fence func() (int64, error)) error
+	}
+)
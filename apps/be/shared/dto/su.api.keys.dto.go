@@ -0,0 +1,25 @@
+package dto
+
+type (
+	CreateApiKeyDTO struct {
+		Name      string   `json:"name" validate:"required,max=200"`
+		Owner     string   `json:"owner" validate:"required,max=200"`
+		Scopes    []string `json:"scopes" validate:"required,min=1,unique,dive,oneof=users:read users:write users:delete search:admin"`
+		ExpiredAt string   `json:"expired_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+		CreatedBy string   `json:"-"`
+	}
+
+	ListApiKeysDTO struct {
+		Limit int64  `query:"limit" validate:"omitempty,number,min=1,max=1000"`
+		Page  int64  `query:"page" validate:"omitempty,number,min=1"`
+		Owner string `query:"owner" validate:"omitempty"`
+	}
+
+	ApiKeyIDDTO struct {
+		ID string `json:"id" validate:"required,uuid"`
+	}
+
+	AuthenticateApiKeyDTO struct {
+		Key string `json:"key" validate:"required"`
+	}
+)
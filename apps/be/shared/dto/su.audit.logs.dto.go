@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+type (
+	ListAuditLogsDTO struct {
+		Limit    int64  `query:"limit" validate:"omitempty,number,min=1,max=1000"`
+		Page     int64  `query:"page" validate:"omitempty,number,min=1"`
+		Actor    string `query:"actor" validate:"omitempty"`
+		TargetID string `query:"target_id" validate:"omitempty"`
+		Action   string `query:"action" validate:"omitempty,oneof=create update delete search"`
+		From     string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+		To       string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	}
+
+	AuditLog struct {
+		Actor      string                    `json:"actor" validate:"required"`
+		ActorType  string                    `json:"actor_type" validate:"required,oneof=user api_key system anonymous"`
+		Action     string                    `json:"action" validate:"required,oneof=create update delete search"`
+		TargetType string                    `json:"target_type" validate:"required"`
+		TargetID   string                    `json:"target_id" validate:"omitempty"`
+		Changes    map[string]AuditLogChange `json:"changes,omitempty" validate:"omitempty"`
+		Metadata   map[string]any            `json:"metadata,omitempty" validate:"omitempty"`
+		RequestID  string                    `json:"request_id" validate:"omitempty"`
+		IPAddress  string                    `json:"ip_address" validate:"omitempty"`
+		OccurredAt time.Time                 `json:"occurred_at" validate:"required"`
+	}
+
+	AuditLogChange struct {
+		Before any `json:"before"`
+		After  any `json:"after"`
+	}
+)
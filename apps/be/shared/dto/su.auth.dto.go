@@ -0,0 +1,32 @@
+package dto
+
+type (
+	LoginDTO struct {
+		Username string `json:"username" validate:"required"`
+		Password string `json:"password" validate:"required"`
+	}
+
+	CreateAccountDTO struct {
+		Username string `json:"username" validate:"required,min=3,max=200"`
+		Password string `json:"password" validate:"required,min=8,max=72"`
+		Role     string `json:"role" validate:"required,oneof=admin support"`
+	}
+
+	UpdateAccountRoleDTO struct {
+		ID   string `json:"id" validate:"required,uuid4"`
+		Role string `json:"role" validate:"required,oneof=admin support"`
+	}
+
+	RefreshTokenDTO struct {
+		RefreshToken string `json:"refresh_token" validate:"required,jwt"`
+	}
+
+	LogoutDTO struct {
+		UserID    string `json:"user_id" validate:"required"`
+		SessionID string `json:"session_id" validate:"required"`
+	}
+
+	RevokeSessionsDTO struct {
+		ID string `json:"id" validate:"required,uuid4"`
+	}
+)
@@ -0,0 +1,18 @@
+package dto
+
+type (
+	ListDeadLettersDTO struct {
+		Limit      int64  `query:"limit" validate:"omitempty,number,min=1,max=1000"`
+		Page       int64  `query:"page" validate:"omitempty,number,min=1"`
+		DocumentID string `query:"document_id" validate:"omitempty"`
+		Status     string `query:"status" validate:"omitempty,oneof=retrying parked replayed discarded"`
+	}
+
+	DeadLetterIDDTO struct {
+		ID string `json:"id" validate:"required,uuid"`
+	}
+
+	DeadLettersFilterDTO struct {
+		DocumentID string `query:"document_id" validate:"omitempty"`
+	}
+)
@@ -0,0 +1,7 @@
+package dto
+
+type (
+	JobNameDTO struct {
+		Name string `json:"name" validate:"required"`
+	}
+)
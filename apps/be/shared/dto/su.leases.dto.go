@@ -0,0 +1,7 @@
+package dto
+
+type (
+	FindLeaseDTO struct {
+		Name string `json:"name" validate:"required"`
+	}
+)
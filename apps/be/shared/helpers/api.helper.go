@@ -2,8 +2,12 @@ package helper
 
 import (
 	"fmt"
+	"net"
 	"net/http"
+	"net/netip"
+	"path"
 	"reflect"
+	"strings"
 	"time"
 
 	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
@@ -27,6 +31,7 @@ var errorCodeMapping = map[int]string{
 	http.StatusForbidden:           "ACCESS_DENIED",
 	http.StatusUnauthorized:        "UNAUTHORIZED_TOKEN",
 	http.StatusNotFound:            "UNKNOWN_RESOURCE",
+	http.StatusTooManyRequests:     "TOO_MANY_REQUESTS",
 	http.StatusInternalServerError: "GENERAL_ERROR",
 }
 
@@ -85,6 +90,71 @@ func getIPAddress(r *http.Request) string {
 	return ip
 }
 
+// IPAddress return client ip of the remote address without port, the forwarded header is resolved by ClientIP
+// before for trusted proxies only, so the client can not choose its own ip
+func IPAddress(r *http.Request) string {
+	ip := strings.TrimSpace(r.RemoteAddr)
+
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+
+	return ip
+}
+
+/**
+* ClientIP read X-Forwarded-For and X-Real-IP only when the request come from a trusted proxy, the forwarded chain
+* is walked from the right and the first address which is not a trusted proxy is the client, otherwise the remote address is kept
+ */
+func ClientIP(r *http.Request, trusted []netip.Prefix) string {
+	remote := IPAddress(r)
+
+	if !trustedIP(remote, trusted) {
+		return remote
+	}
+
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		chain := strings.Split(forwarded, ",")
+		client := remote
+
+		for i := len(chain) - 1; i >= 0; i-- {
+			addr, err := netip.ParseAddr(strings.TrimSpace(chain[i]))
+			if err != nil {
+				return remote
+			}
+
+			client = addr.Unmap().String()
+
+			if !trustedIP(client, trusted) {
+				return client
+			}
+		}
+
+		return client
+	}
+
+	if addr, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get("X-Real-IP"))); err == nil {
+		return addr.Unmap().String()
+	}
+
+	return remote
+}
+
+func trustedIP(ip string, trusted []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+
+	for _, prefix := range trusted {
+		if prefix.Contains(addr.Unmap()) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func buildResponse(options opt.Response, r *http.Request, rt *responseTimer) opt.Response {
 	response := opt.Response{
 		StatCode: http.StatusInternalServerError,
@@ -196,3 +266,34 @@ func writeResponse(rw http.ResponseWriter, parser inf.IParser, response opt.Resp
 		fmt.Fprint(rw, errorResponse)
 	}
 }
+
+/**
+* MatchRoute match method and path against pattern like path.Match, where * never cross a slash, pattern ending
+* with /** also match every path below the prefix, example: * /api/v1/** match GET /api/v1/users/1
+ */
+func MatchRoute(pattern, route string) bool {
+	prefix, ok := strings.CutSuffix(pattern, "/**")
+	if !ok {
+		matched, _ := path.Match(pattern, route)
+		return matched
+	}
+
+	segments := strings.Count(prefix, "/")
+	end := len(route)
+
+	for i, char := range route {
+		if char != '/' {
+			continue
+		}
+
+		if segments == 0 {
+			end = i
+			break
+		}
+
+		segments--
+	}
+
+	matched, _ := path.Match(prefix, route[:end])
+	return matched
+}
@@ -0,0 +1,47 @@
+package helper
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+)
+
+/**
+* GenerateApiKey create api key with format fsk_<prefix>_<secret>, prefix is stored in plain text to lookup the key
+* and only sha256 hash of the whole key is stored, so the key can not be shown again after creation
+ */
+func GenerateApiKey() (key string, prefix string, err error) {
+	prefixBytes := make([]byte, cons.API_KEY_PREFIX_LENGTH)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return "", "", err
+	}
+
+	secretBytes := make([]byte, cons.API_KEY_SECRET_LENGTH)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", "", err
+	}
+
+	prefix = hex.EncodeToString(prefixBytes)
+	key = fmt.Sprintf("%s_%s_%s", cons.API_KEY_SCHEME, prefix, base64.RawURLEncoding.EncodeToString(secretBytes))
+
+	return key, prefix, nil
+}
+
+func ParseApiKey(key string) (prefix string, ok bool) {
+	segments := strings.SplitN(key, "_", 3)
+	if len(segments) != 3 || segments[0] != cons.API_KEY_SCHEME || len(segments[1]) != cons.API_KEY_PREFIX_LENGTH*2 || segments[2] == "" {
+		return "", false
+	}
+
+	return segments[1], true
+}
+
+func HashApiKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
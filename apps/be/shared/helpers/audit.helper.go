@@ -0,0 +1,189 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+var (
+	auditPersonalFields = []string{"name", "email", "phone", "date_of_birth", "address", "postal_code"}
+	auditIgnoredFields  = []string{"id", "is_sync", "created_at", "updated_at", "deleted_at"}
+)
+
+// AuditActor return principal verified by api key or auth middleware, request without principal is anonymous
+func AuditActor(ctx context.Context) (string, string) {
+	if public, _ := ctx.Value(cons.AUTH_PUBLIC).(bool); public {
+		return cons.AUDIT_ACTOR_ANONYMOUS, cons.AUDIT_ACTOR_ANONYMOUS
+	}
+
+	actor, _ := ctx.Value(cons.AUTH_USER_ID).(string)
+	if actor == "" {
+		return cons.AUDIT_ACTOR_ANONYMOUS, cons.AUDIT_ACTOR_ANONYMOUS
+	}
+
+	if apiKeyID, _ := ctx.Value(cons.AUTH_API_KEY_ID).(string); apiKeyID != "" {
+		return actor, cons.AUDIT_ACTOR_API_KEY
+	}
+
+	return actor, cons.AUDIT_ACTOR_USER
+}
+
+/**
+* AuditHash hash personal data before it reach the audit log, it is keyed with AUDIT_HASH_KEY when configured
+* so short value like phone can not be guessed back, the same value always give the same hash so the change is traceable
+ */
+func AuditHash(env dto.Request[dto.Environtment], value any) any {
+	if value == nil {
+		return nil
+	}
+
+	plainText := fmt.Sprint(value)
+	if plainText == "" {
+		return plainText
+	}
+
+	crypto := NewCrypto()
+
+	var (
+		hash string
+		err  error
+	)
+
+	if env.Config.AUDIT.HASH_KEY != "" {
+		hash, err = crypto.HMACSHA512Sign(env.Config.AUDIT.HASH_KEY, plainText)
+	} else {
+		hash, err = crypto.SHA256Sign(plainText)
+	}
+
+	if err != nil {
+		return cons.REDACTED
+	}
+
+	return hash
+}
+
+// AuditFields hash personal field of the map, used for value outside of changes like search filter
+func AuditFields(env dto.Request[dto.Environtment], fields map[string]any) map[string]any {
+	res := make(map[string]any, len(fields))
+
+	for field, value := range fields {
+		if slices.Contains(auditPersonalFields, field) {
+			value = AuditHash(env, value)
+		}
+
+		res[field] = value
+	}
+
+	return res
+}
+
+/**
+* AuditChanges return field changed between before and after, nil before is a created entry and nil after is a deleted entry,
+* bookkeeping field is ignored and personal field is hashed on both side
+ */
+func AuditChanges(env dto.Request[dto.Environtment], before, after any) (map[string]dto.AuditLogChange, error) {
+	transform := NewTransform()
+
+	beforeFields := make(map[string]any)
+	afterFields := make(map[string]any)
+
+	if before != nil {
+		if err := transform.SrcToDest(before, &beforeFields); err != nil {
+			return nil, err
+		}
+	}
+
+	if after != nil {
+		if err := transform.SrcToDest(after, &afterFields); err != nil {
+			return nil, err
+		}
+	}
+
+	changes := make(map[string]dto.AuditLogChange)
+
+	fields := maps.Clone(beforeFields)
+	maps.Copy(fields, afterFields)
+
+	for field := range fields {
+		if slices.Contains(auditIgnoredFields, field) {
+			continue
+		}
+
+		beforeValue, afterValue := beforeFields[field], afterFields[field]
+		if reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+
+		if slices.Contains(auditPersonalFields, field) {
+			beforeValue, afterValue = AuditHash(env, beforeValue), AuditHash(env, afterValue)
+		}
+
+		changes[field] = dto.AuditLogChange{Before: beforeValue, After: afterValue}
+	}
+
+	return changes, nil
+}
+
+/**
+* AuditPublisher publish the audit entry of the principal in context, the audit worker write it to the audit log,
+* search without principal is not recorded while change without principal is recorded as anonymous
+ */
+func AuditPublisher(ctx context.Context, broker inf.IBroker, req dto.AuditLog) error {
+	if req.Actor == "" {
+		req.Actor, req.ActorType = AuditActor(ctx)
+	}
+
+	if req.ActorType == cons.AUDIT_ACTOR_ANONYMOUS && req.Action == cons.AUDIT_ACTION_SEARCH {
+		return nil
+	}
+
+	if apiKeyID, _ := ctx.Value(cons.AUTH_API_KEY_ID).(string); apiKeyID != "" {
+		if req.Metadata == nil {
+			req.Metadata = make(map[string]any)
+		}
+
+		req.Metadata[cons.AUTH_API_KEY_ID] = apiKeyID
+	}
+
+	req.RequestID = middleware.GetReqID(ctx)
+	req.IPAddress, _ = ctx.Value(cons.AUDIT_IP_ADDRESS).(string)
+	req.OccurredAt = time.Now()
+
+	event := dto.Event[dto.AuditLog]{}
+	event.ID = uuid.NewString()
+	event.Type = cons.EVENT_TYPE_AUDIT_LOG
+	event.Source = cons.EVENT_SOURCE_API
+	event.SpecVersion = cons.EVENT_SPEC_VERSION
+	event.DataVersion = cons.EVENT_VERSION_AUDIT_LOG
+	event.CorrelationID = req.RequestID
+	event.Time = req.OccurredAt
+	event.Data = req
+
+	if event.CorrelationID == "" {
+		event.CorrelationID = event.ID
+	}
+
+	amqp_req := dto.Request[dto.BrokerOptions]{}
+
+	amqp_req.Option.ExchangeName = cons.EXCHANGE_NAME_AUDIT
+	amqp_req.Option.ExchangeType = cons.EXCHANGE_TYPE_DIRECT
+	amqp_req.Option.QueueName = cons.QUEUE_NAME_AUDIT
+	amqp_req.Option.Body = event
+
+	if err := broker.Publisher(amqp_req); err != nil {
+		return err
+	}
+
+	return nil
+}
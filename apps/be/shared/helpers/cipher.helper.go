@@ -120,7 +120,7 @@ func (h cipher) HMACSHA512Verify(secretKey, data, hash string) bool {
 		return false
 	}
 
-	return hmac.Equal([]byte(hash), hashHMAC512.Sum(nil))
+	return hmac.Equal([]byte(hash), []byte(hex.EncodeToString(hashHMAC512.Sum(nil))))
 }
 
 func (h cipher) SHA256Sign(plainText string) (string, error) {
@@ -1,40 +1,101 @@
 package helper
 
 import (
+	"context"
+	"fmt"
+	"hash/fnv"
 	"reflect"
+	"strings"
 	"time"
 
-	"github.com/wagslane/go-rabbitmq"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
 
 	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
 	"github.com/restuwahyu13/go-fast-search/shared/dto"
 	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
 )
 
-func MeiliSearchPublisher[T any](amqp inf.IRabbitMQ, secret string, id any, data T, isBulk bool, action string) error {
-	usersDocReq := dto.MeiliSearchDocuments[T]{}
+func MeiliSearchPublisher[T any](ctx context.Context, broker inf.IBroker, env dto.Request[dto.Environtment], id any, data T, isBulk bool, action string) error {
+	envelope := NewEnvelope(env)
+
+	usersDocReq := dto.MeiliSearchDocuments[any]{}
 	usersDocReq.ID = id
 	usersDocReq.Doc = "users"
-	usersDocReq.Data = any(data).(T)
+	usersDocReq.Data = data
 	usersDocReq.IsBulk = isBulk
 	usersDocReq.Action = action
 
-	amqp_req := dto.Request[dto.RabbitOptions]{}
+	if envelope.Enabled() {
+		envelopeKey, cipherText, err := envelope.Seal(data)
+		if err != nil {
+			return err
+		}
+
+		usersDocReq.Data = cipherText
+		usersDocReq.Encryption = envelopeKey
+	}
+
+	event := dto.Event[dto.MeiliSearchDocuments[any]]{}
+	event.ID = uuid.NewString()
+	event.Type = cons.EVENT_TYPE_SEARCH_DOCUMENT
+	event.Source = cons.EVENT_SOURCE_API
+	event.SpecVersion = cons.EVENT_SPEC_VERSION
+	event.DataVersion = cons.EVENT_VERSION_SEARCH_DOCUMENT
+	event.CorrelationID = middleware.GetReqID(ctx)
+	event.Time = time.Now()
+	event.Data = usersDocReq
+
+	if event.CorrelationID == "" {
+		event.CorrelationID = event.ID
+	}
+
+	amqp_req := dto.Request[dto.BrokerOptions]{}
 
 	amqp_req.Option.ExchangeName = cons.EXCHANGE_NAME_SEARCH
 	amqp_req.Option.ExchangeType = cons.EXCHANGE_TYPE_DIRECT
-	amqp_req.Option.QueueName = cons.QUEUE_NAME_SEARCH
+	amqp_req.Option.QueueName = PartitionQueue(cons.QUEUE_NAME_SEARCH_PARTITION, DocumentKey(id, data), env.Config.RABBITMQ.PARTITIONS)
 
-	amqp_req.Option.Body = usersDocReq
-	amqp_req.Option.Args = rabbitmq.Table{cons.X_RABBIT_SECRET: secret}
+	amqp_req.Option.Body = event
 
-	if err := amqp.Publisher(amqp_req); err != nil {
+	if err := broker.Publisher(amqp_req); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+func Partitions(partitions int) int {
+	if partitions < 1 {
+		return cons.QUEUE_PARTITIONS
+	}
+
+	return partitions
+}
+
+/**
+* Consistent partition for the document key, the same document always land in the same queue
+ */
+func PartitionQueue(queue, key string, partitions int) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+
+	return fmt.Sprintf(queue, hash.Sum32()%uint32(Partitions(partitions)))
+}
+
+func DocumentKey(id, data any) string {
+	if id != nil {
+		return fmt.Sprint(id)
+	}
+
+	doc := make(map[string]any)
+	if err := NewTransform().SrcToDest(data, &doc); err != nil || doc["id"] == nil {
+		return ""
+	}
+
+	return fmt.Sprint(doc["id"])
+}
+
 func NextSleepBackoff[T any](req dto.Request[dto.SleepBackoff], handler func() (T, error)) (T, error) {
 	cmdIncrBy := req.Config.Redis.IncrBy(req.Body.Ctx, req.Body.Key, req.Body.Count)
 	if err := cmdIncrBy.Err(); err != nil {
@@ -124,3 +185,31 @@ func TimeStampToUnix(timestamp any) (int64, error) {
 
 	return tparse.Unix(), nil
 }
+
+func FilterIn(field string, values ...string) string {
+	quoted := make([]string, 0, len(values))
+
+	for _, value := range values {
+		quoted = append(quoted, fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", "\\'")))
+	}
+
+	return fmt.Sprintf("%s IN [%s]", field, strings.Join(quoted, ", "))
+}
+
+// DeletedAt return deleted time of the document data as unix, zero when the data carry no deleted time
+func DeletedAt(data map[string]any) int64 {
+	switch deletedAt := data["deleted_at"].(type) {
+
+	case float64:
+		return int64(deletedAt)
+
+	case int64:
+		return deletedAt
+
+	case int:
+		return int64(deletedAt)
+
+	default:
+		return 0
+	}
+}
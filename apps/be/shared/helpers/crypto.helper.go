@@ -21,23 +21,38 @@ func NewCrypto() inf.ICrypto {
 }
 
 func (h crypto) AES256Encrypt(secretKey, plainText string) (string, error) {
-	secretKeyByte := make([]byte, len(secretKey))
-	secretKeyByte = []byte(secretKey)
-
-	plainTextByte := make([]byte, len(plainText))
-	plainTextByte = []byte(plainText)
-
-	tagSize := 16
-
-	if len(secretKeyByte) < 32 {
-		return "", errors.New("Secretkey length mismatch")
+	key, err := h.AES256Key(secretKey)
+	if err != nil {
+		return "", err
 	}
 
-	key, err := scrypt.Key([]byte(secretKey), []byte("salt"), 1024, 8, 1, 32)
+	return h.AES256EncryptWithKey(key, plainText)
+}
+
+func (h crypto) AES256Decrypt(secretKey string, cipherText string) (string, error) {
+	key, err := h.AES256Key(secretKey)
 	if err != nil {
 		return "", err
 	}
 
+	return h.AES256DecryptWithKey(key, cipherText)
+}
+
+/**
+* Derive the aes key from the secret key, the derived key can be cached by the caller
+* because scrypt is expensive to run for every message
+ */
+func (h crypto) AES256Key(secretKey string) ([]byte, error) {
+	if len([]byte(secretKey)) < 32 {
+		return nil, errors.New("Secretkey length mismatch")
+	}
+
+	return scrypt.Key([]byte(secretKey), []byte("salt"), 1024, 8, 1, 32)
+}
+
+func (h crypto) AES256EncryptWithKey(key []byte, plainText string) (string, error) {
+	tagSize := 16
+
 	block, err := aes.NewCipher(key)
 	if err != nil {
 		return "", err
@@ -53,25 +68,14 @@ func (h crypto) AES256Encrypt(secretKey, plainText string) (string, error) {
 		return "", err
 	}
 
-	cipherText := gcm.Seal(nonceSize, nonceSize, []byte(plainTextByte), nil)
+	cipherText := gcm.Seal(nonceSize, nonceSize, []byte(plainText), nil)
 
 	return hex.EncodeToString(cipherText), nil
 }
 
-func (h crypto) AES256Decrypt(secretKey string, cipherText string) (string, error) {
-	secretKeyByte := make([]byte, len(secretKey))
-	secretKeyByte = []byte(secretKey)
+func (h crypto) AES256DecryptWithKey(key []byte, cipherText string) (string, error) {
 	tagSize := 16
 
-	if len(secretKeyByte) < 32 {
-		return "", errors.New("Secretkey length mismatch")
-	}
-
-	key, err := scrypt.Key(secretKeyByte, []byte("salt"), 1024, 8, 1, 32)
-	if err != nil {
-		return "", err
-	}
-
 	cipherTextByte, err := hex.DecodeString(cipherText)
 	if err != nil {
 		return "", err
@@ -87,15 +91,13 @@ func (h crypto) AES256Decrypt(secretKey string, cipherText string) (string, erro
 		return "", err
 	}
 
-	nonceSize := make([]byte, gcm.NonceSize())
-	if _, err = rand.Read(nonceSize); err != nil {
-		return "", err
-	} else if len(cipherTextByte) < len(nonceSize) {
+	nonceSize := gcm.NonceSize()
+	if len(cipherTextByte) < nonceSize {
 		return "", errors.New("Cipher text to short")
 	}
 
-	nonce, ciphertext := cipherTextByte[:len(nonceSize)], cipherTextByte[len(nonceSize):]
-	plaintext, err := gcm.Open(nil, []byte(nonce), []byte(ciphertext), nil)
+	nonce, ciphertext := cipherTextByte[:nonceSize], cipherTextByte[nonceSize:]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
 	if err != nil {
 		return "", err
 	}
@@ -120,7 +122,7 @@ func (h crypto) HMACSHA512Verify(secretKey, data, hash string) bool {
 		return false
 	}
 
-	return hmac.Equal([]byte(hash), hashHMAC512.Sum(nil))
+	return hmac.Equal([]byte(hash), []byte(hex.EncodeToString(hashHMAC512.Sum(nil))))
 }
 
 func (h crypto) SHA256Sign(plainText string) (string, error) {
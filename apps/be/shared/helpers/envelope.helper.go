@@ -0,0 +1,147 @@
+package helper
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"sync"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type envelope struct {
+	crypto inf.ICrypto
+	keyID  string
+	keys   map[string]string
+	kek    *sync.Map
+}
+
+/**
+* Envelope encryption of the search document data, every message get its own data key and only
+* the data key is encrypted with the configured key, encryption is disabled without BROKER_ENCRYPTION_KEYS
+ */
+func NewEnvelope(env dto.Request[dto.Environtment]) inf.IEnvelope {
+	return envelope{crypto: NewCrypto(), keyID: env.Config.BROKER.ENCRYPTION_KEY_ID, keys: env.Config.BROKER.ENCRYPTION_KEYS, kek: new(sync.Map)}
+}
+
+/**
+* Key encryption key is derived once per key id, scrypt is too expensive to run for every message
+ */
+func (h envelope) key(keyID string) ([]byte, error) {
+	if kek, ok := h.kek.Load(keyID); ok {
+		return kek.([]byte), nil
+	}
+
+	secretKey, ok := h.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cons.ENVELOPE_UNKNOWN_KEY, keyID)
+	}
+
+	kek, err := h.crypto.AES256Key(secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	h.kek.Store(keyID, kek)
+
+	return kek, nil
+}
+
+func (h envelope) Enabled() bool {
+	return len(h.keys) > 0
+}
+
+func (h envelope) Seal(data any) (*dto.EnvelopeKey, string, error) {
+	parser := NewParser()
+
+	key, err := h.key(h.keyID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dataByte, err := parser.Marshal(&data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dataKeyByte := make([]byte, 32)
+	if _, err := rand.Read(dataKeyByte); err != nil {
+		return nil, "", err
+	}
+
+	// data key is random 32 bytes and used as the aes key directly, only the key encryption key is derived
+	cipherText, err := h.crypto.AES256EncryptWithKey(dataKeyByte, string(dataByte))
+	if err != nil {
+		return nil, "", err
+	}
+
+	wrappedKey, err := h.crypto.AES256EncryptWithKey(key, hex.EncodeToString(dataKeyByte))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &dto.EnvelopeKey{KeyID: h.keyID, Key: wrappedKey}, cipherText, nil
+}
+
+/**
+* Open return a decrypted copy of the document, the given document keep the cipher text
+* so the message forwarded to dead letter queue is never stored in plain text
+ */
+func (h envelope) Open(document map[string]any) (map[string]any, error) {
+	parser := NewParser()
+	transform := NewTransform()
+
+	if document["encryption"] == nil {
+		return document, nil
+	}
+
+	envelopeKey := dto.EnvelopeKey{}
+	if err := transform.SrcToDest(document["encryption"], &envelopeKey); err != nil {
+		return nil, fmt.Errorf("%w: %v", cons.ENVELOPE_INVALID, err)
+	}
+
+	cipherText, ok := document["data"].(string)
+	if !ok {
+		return nil, cons.ENVELOPE_INVALID
+	}
+
+	key, err := h.key(envelopeKey.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey, err := h.crypto.AES256DecryptWithKey(key, envelopeKey.Key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", cons.ENVELOPE_INVALID, err)
+	}
+
+	dataKeyByte, err := hex.DecodeString(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", cons.ENVELOPE_INVALID, err)
+	}
+
+	/**
+	* Message sealed before the data key was used directly had its data key derived with scrypt,
+	* it is only tried when the direct key fail so the new message never pay for the derivation
+	 */
+	plainText, err := h.crypto.AES256DecryptWithKey(dataKeyByte, cipherText)
+	if err != nil {
+		if plainText, err = h.crypto.AES256Decrypt(dataKey, cipherText); err != nil {
+			return nil, fmt.Errorf("%w: %v", cons.ENVELOPE_INVALID, err)
+		}
+	}
+
+	var data any
+	if err := parser.Unmarshal([]byte(plainText), &data); err != nil {
+		return nil, fmt.Errorf("%w: %v", cons.ENVELOPE_INVALID, err)
+	}
+
+	res := maps.Clone(document)
+	res["data"] = data
+	delete(res, "encryption")
+
+	return res, nil
+}
@@ -0,0 +1,140 @@
+package helper
+
+import (
+	"regexp"
+	"strings"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+)
+
+var (
+	redactJSON  = regexp.MustCompile(`"(name|email|phone|date_of_birth|address|postal_code)"(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	redactKey   = regexp.MustCompile(`^(\w+):`)
+	redactEmail = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	redactPhone = regexp.MustCompile(`\+[1-9]\d{7,14}\b`)
+	redactKeys  = map[string]bool{"name": true, "email": true, "phone": true, "date_of_birth": true, "address": true, "postal_code": true}
+)
+
+/**
+* Redact personal data from log message, json field and go map formatted field of the user document
+* are masked by name, email and e164 phone are masked wherever they appear
+ */
+func Redact(message string) string {
+	message = redactJSON.ReplaceAllString(message, `"$1"$2"`+cons.REDACTED+`"`)
+	message = redactMaps(message)
+	message = redactEmail.ReplaceAllString(message, cons.REDACTED)
+	message = redactPhone.ReplaceAllString(message, cons.REDACTED)
+
+	return message
+}
+
+/**
+* Only the value of known key inside go map formatted as map[key:value key:value] is masked,
+* the value run until the next key or the end of the map so value with space is masked entirely
+ */
+func redactMaps(message string) string {
+	res := strings.Builder{}
+	depth := 0
+
+	for i := 0; i < len(message); {
+		if strings.HasPrefix(message[i:], "map[") {
+			depth++
+			res.WriteString("map[")
+			i += 4
+
+			i = redactMapEntry(&res, message, i)
+			continue
+		}
+
+		if depth > 0 && message[i] == '[' {
+			depth++
+		}
+
+		if depth > 0 && message[i] == ']' {
+			depth--
+		}
+
+		res.WriteByte(message[i])
+
+		if depth > 0 && message[i] == ' ' {
+			i = redactMapEntry(&res, message, i+1)
+			continue
+		}
+
+		i++
+	}
+
+	return res.String()
+}
+
+func redactMapEntry(res *strings.Builder, message string, start int) int {
+	key := redactKey.FindStringSubmatch(message[start:])
+	if key == nil || !redactKeys[key[1]] {
+		return start
+	}
+
+	res.WriteString(key[0] + cons.REDACTED)
+
+	depth := 0
+
+	for i := start + len(key[0]); i < len(message); i++ {
+		switch {
+		case message[i] == '[':
+			depth++
+
+		case message[i] == ']' && depth == 0:
+			return i
+
+		case message[i] == ']':
+			depth--
+
+		case message[i] == ' ' && depth == 0 && redactKey.MatchString(message[i+1:]):
+			return i
+		}
+	}
+
+	return len(message)
+}
+
+// MaskEmail keep first and last character of local part and the domain, example: j******e@mail.com
+func MaskEmail(email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" {
+		return mask(email, 0)
+	}
+
+	if len(local) < 3 {
+		return strings.Repeat("*", len(local)) + "@" + domain
+	}
+
+	return local[:1] + strings.Repeat("*", len(local)-2) + local[len(local)-1:] + "@" + domain
+}
+
+// MaskPhone keep last four digit, example: *********6789
+func MaskPhone(phone string) string {
+	return mask(phone, 4)
+}
+
+// MaskDate keep the year of date formatted as 2006-01-02, example: 1990-**-**
+func MaskDate(date string) string {
+	year, rest, ok := strings.Cut(date, "-")
+	if !ok {
+		return mask(date, 0)
+	}
+
+	return year + "-" + strings.Map(func(r rune) rune {
+		if r == '-' {
+			return r
+		}
+
+		return '*'
+	}, rest)
+}
+
+func mask(value string, visible int) string {
+	if len(value) <= visible {
+		return strings.Repeat("*", len(value))
+	}
+
+	return strings.Repeat("*", len(value)-visible) + value[len(value)-visible:]
+}
@@ -0,0 +1,33 @@
+package helper
+
+import (
+	"slices"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+)
+
+// Permissions return permissions granted to role, unknown role is granted nothing
+func Permissions(role string) []string {
+	switch role {
+
+	case cons.ROLE_ADMIN:
+		return []string{cons.PERMISSION_USERS_READ, cons.PERMISSION_USERS_WRITE, cons.PERMISSION_USERS_DELETE, cons.PERMISSION_SEARCH_ADMIN, cons.PERMISSION_KEYS_ADMIN, cons.PERMISSION_AUDIT_READ}
+
+	case cons.ROLE_SUPPORT:
+		return []string{cons.PERMISSION_USERS_READ}
+
+	default:
+		return []string{}
+	}
+}
+
+// Role return the most privileged known role, used when external identity carry several roles
+func Role(roles ...string) string {
+	for _, role := range []string{cons.ROLE_ADMIN, cons.ROLE_SUPPORT} {
+		if slices.Contains(roles, role) {
+			return role
+		}
+	}
+
+	return ""
+}
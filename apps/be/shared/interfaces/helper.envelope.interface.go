@@ -0,0 +1,9 @@
+package inf
+
+import "github.com/restuwahyu13/go-fast-search/shared/dto"
+
+type IEnvelope interface {
+	Enabled() bool
+	Seal(data any) (*dto.EnvelopeKey, string, error)
+	Open(document map[string]any) (map[string]any, error)
+}
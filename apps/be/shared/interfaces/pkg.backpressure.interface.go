@@ -0,0 +1,13 @@
+package inf
+
+import (
+	"context"
+
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type IBackpressure interface {
+	Run(ctx context.Context, handler func(paused bool))
+	IsPaused() bool
+	Metrics() opt.BackpressureMetadata
+}
@@ -0,0 +1,5 @@
+package inf
+
+type IBatcher interface {
+	Add(doc, action, id string, data map[string]any) <-chan error
+}
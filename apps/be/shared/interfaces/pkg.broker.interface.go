@@ -0,0 +1,9 @@
+package inf
+
+import "github.com/restuwahyu13/go-fast-search/shared/dto"
+
+type IBroker interface {
+	Publisher(req dto.Request[dto.BrokerOptions]) error
+	Consumer(req dto.Request[dto.BrokerOptions], callback func(d dto.BrokerDelivery) (action dto.BrokerAction)) (stop func())
+	Delay(req dto.Request[dto.BrokerOptions], attempt int) error
+}
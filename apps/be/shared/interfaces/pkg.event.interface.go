@@ -0,0 +1,8 @@
+package inf
+
+import "github.com/restuwahyu13/go-fast-search/shared/dto"
+
+type IEventRegistry interface {
+	Register(schema dto.EventSchema) error
+	Decode(body []byte) (*dto.Event[map[string]any], error)
+}
@@ -0,0 +1,8 @@
+package inf
+
+type IIdempotency interface {
+	Claim(eventID string) (bool, error)
+	Release(eventID string) error
+	Advance(doc, id string, version int64) (bool, error)
+	Count(kind string) (int64, error)
+}
@@ -16,5 +16,5 @@ type IJose interface {
 	ImportJsonWebKey(jwkKey jwk.Key) (*opt.JwkMetadata, error)
 	ExportJsonWebKey(privateKey *rsa.PrivateKey) (*opt.JwkMetadata, error)
 	JwtSign(options *dto.JwtSignOption) ([]byte, error)
-	JwtVerify(prefix string, token string, redis IRedis) (*jwt.Token, error)
+	JwtVerify(prefix string, token string, redis IRedis, keyset IKeyset) (*jwt.Token, error)
 }
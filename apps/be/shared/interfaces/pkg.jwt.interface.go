@@ -6,4 +6,6 @@ import (
 
 type IJsonWebToken interface {
 	Sign(prefix string, body any) (*opt.SignMetadata, error)
+	Verify(token string) (*opt.VerifyMetadata, error)
+	Revoke(prefix string) error
 }
@@ -0,0 +1,14 @@
+package inf
+
+import (
+	"github.com/lestrrat-go/jwx/v3/jwk"
+
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type IKeyset interface {
+	Active() (*opt.SigningKeyMetadata, error)
+	Key(kid string) (*opt.SigningKeyMetadata, error)
+	Rotate() (*opt.SigningKeyMetadata, error)
+	Jwks() (jwk.Set, error)
+}
@@ -0,0 +1,18 @@
+package inf
+
+import (
+	"context"
+
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type ILease interface {
+	Run(ctx context.Context)
+	Acquire() (bool, error)
+	Renew() (bool, error)
+	Release() error
+	IsLeader() bool
+	Token() int64
+	Fence() (int64, error)
+	Status() (*opt.LeaseMetadata, error)
+}
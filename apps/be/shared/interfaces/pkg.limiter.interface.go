@@ -0,0 +1,11 @@
+package inf
+
+import (
+	"time"
+
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type ILimiter interface {
+	Allow(key string, limit int, window time.Duration) (*opt.RateLimitMetadata, error)
+}
@@ -9,10 +9,13 @@ type IMeiliSearch interface {
 	Like(doc string, query string, filter *meilisearch.SearchRequest, dest *meilisearch.SearchResponse) error
 	Insert(doc string, value any) (*meilisearch.TaskInfo, error)
 	Update(doc string, id string, value any) (*meilisearch.TaskInfo, error)
-	Delete(doc string, id string) (*meilisearch.TaskInfo, error)
+	Delete(doc string, id string, deletedAt int64) (*meilisearch.TaskInfo, error)
 	BulkInsert(doc string, value any) (*meilisearch.TaskInfo, error)
 	BulkUpdate(doc string, value any) (*meilisearch.TaskInfo, error)
-	BulkDelete(doc string, ids ...string) (*meilisearch.TaskInfo, error)
+	BulkDelete(doc string, deletedAt map[string]int64) (*meilisearch.TaskInfo, error)
+	BulkDestroy(doc string, ids ...string) (*meilisearch.TaskInfo, error)
+	Upsert(doc string, value any) (*meilisearch.TaskInfo, error)
+	Wait(task *meilisearch.TaskInfo) error
 	GetStats(doc string) (*meilisearch.StatsIndex, error)
 	UpdateTypoTolerance(doc string, request *meilisearch.TypoTolerance) (*meilisearch.TaskInfo, error)
 	UpdateFilterableAttributes(doc string, request []string) ([]string, error)
@@ -0,0 +1,10 @@
+package inf
+
+import (
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type IOidc interface {
+	Issued(token string) bool
+	Verify(token string) (*opt.OidcMetadata, error)
+}
@@ -0,0 +1,14 @@
+package inf
+
+import (
+	"time"
+)
+
+type IRetry interface {
+	Topology(exchange, queue string) error
+	Attempt(headers map[string]any) int
+	Exhausted(attempt int) bool
+	Queue(queue string, attempt int) string
+	Delay(attempt int) time.Duration
+	Expiration(attempt int) string
+}
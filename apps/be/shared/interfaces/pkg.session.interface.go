@@ -0,0 +1,12 @@
+package inf
+
+import (
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type ISession interface {
+	Create(subject string, claim any) (*opt.SessionMetadata, error)
+	Refresh(token string) (*opt.SessionMetadata, error)
+	Revoke(subject, session string) error
+	RevokeAll(subject string) (int, error)
+}
@@ -0,0 +1,12 @@
+package inf
+
+import (
+	"time"
+
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+)
+
+type ISigner interface {
+	Sign(req *dto.Request[dto.BrokerOptions], delay time.Duration) error
+	Verify(d dto.BrokerDelivery) error
+}
@@ -1,7 +1,44 @@
 package inf
 
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
 type (
 	ISearchScheduler interface {
-		SearchRun()
+		SearchHandler(ctx context.Context, fence func() (int64, error)) error
+	}
+
+	IKeysetScheduler interface {
+		RotateHandler(ctx context.Context, fence func() (int64, error)) error
+	}
+
+	IJobControl interface {
+		Jobs() []opt.JobMetadata
+		Runs() []opt.JobRunMetadata
+		Trigger(name string) error
+		Pause(name string) error
+		Resume(name string) error
+	}
+
+	IJobRegistry interface {
+		IJobControl
+		Register(job dto.Job) error
+		Start()
+		Shutdown(timeout time.Duration) error
+	}
+
+	ISchedulerRunsRepositorie interface {
+		Find() *bun.SelectQuery
+		FindOne() *bun.SelectQuery
+		Insert(entitie entitie.SchedulerRunsEntitie, column string, dest ...any) error
+		Update(entitie entitie.SchedulerRunsEntitie) error
 	}
 )
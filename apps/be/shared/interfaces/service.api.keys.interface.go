@@ -0,0 +1,51 @@
+package inf
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type (
+	IApiKeysRepositorie interface {
+		Find() *bun.SelectQuery
+		FindOne() *bun.SelectQuery
+		Insert(entitie entitie.ApiKeysEntitie, column string, dest ...any) error
+		Update(entitie entitie.ApiKeysEntitie) error
+		Touch(id string, interval time.Duration) error
+	}
+
+	IApiKeysService interface {
+		CreateApiKey(ctx context.Context, req dto.Request[dto.CreateApiKeyDTO]) (res opt.Response)
+		FindAllApiKeys(ctx context.Context, req dto.Request[dto.ListApiKeysDTO]) (res opt.Response)
+		RotateApiKey(ctx context.Context, req dto.Request[dto.ApiKeyIDDTO]) (res opt.Response)
+		RevokeApiKey(ctx context.Context, req dto.Request[dto.ApiKeyIDDTO]) (res opt.Response)
+		AuthenticateApiKey(ctx context.Context, req dto.Request[dto.AuthenticateApiKeyDTO]) (res opt.Response)
+	}
+
+	IApiKeysException interface {
+		CreateApiKey(key string) string
+		ApiKey(key string) string
+		AuthenticateApiKey(key string) string
+	}
+
+	IApiKeysUsecase interface {
+		CreateApiKey(ctx context.Context, req dto.Request[dto.CreateApiKeyDTO]) opt.Response
+		FindAllApiKeys(ctx context.Context, req dto.Request[dto.ListApiKeysDTO]) opt.Response
+		RotateApiKey(ctx context.Context, req dto.Request[dto.ApiKeyIDDTO]) opt.Response
+		RevokeApiKey(ctx context.Context, req dto.Request[dto.ApiKeyIDDTO]) opt.Response
+	}
+
+	IApiKeysController interface {
+		CreateApiKey(rw http.ResponseWriter, r *http.Request)
+		FindAllApiKeys(rw http.ResponseWriter, r *http.Request)
+		RotateApiKey(rw http.ResponseWriter, r *http.Request)
+		RevokeApiKey(rw http.ResponseWriter, r *http.Request)
+	}
+)
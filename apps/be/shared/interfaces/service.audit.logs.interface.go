@@ -0,0 +1,35 @@
+package inf
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/uptrace/bun"
+
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type (
+	IAuditLogsRepositorie interface {
+		Find() *bun.SelectQuery
+		Insert(entities ...entitie.AuditLogsEntitie) error
+	}
+
+	IAuditLogsService interface {
+		FindAllAuditLogs(ctx context.Context, req dto.Request[dto.ListAuditLogsDTO]) (res opt.Response)
+	}
+
+	IAuditLogsException interface {
+		FindAllAuditLogs(key string) string
+	}
+
+	IAuditLogsUsecase interface {
+		FindAllAuditLogs(ctx context.Context, req dto.Request[dto.ListAuditLogsDTO]) opt.Response
+	}
+
+	IAuditLogsController interface {
+		FindAllAuditLogs(rw http.ResponseWriter, r *http.Request)
+	}
+)
@@ -0,0 +1,53 @@
+package inf
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/uptrace/bun"
+
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type (
+	IAccountsRepositorie interface {
+		Find() *bun.SelectQuery
+		FindOne() *bun.SelectQuery
+		Insert(entitie entitie.AccountsEntitie, column string, dest ...any) error
+		Update(entitie entitie.AccountsEntitie) error
+	}
+
+	IAuthService interface {
+		Login(ctx context.Context, req dto.Request[dto.LoginDTO]) (res opt.Response)
+		Refresh(ctx context.Context, req dto.Request[dto.RefreshTokenDTO]) (res opt.Response)
+		Logout(ctx context.Context, req dto.Request[dto.LogoutDTO]) (res opt.Response)
+		Jwks(ctx context.Context) (res opt.Response)
+		RevokeSessions(ctx context.Context, req dto.Request[dto.RevokeSessionsDTO]) (res opt.Response)
+		CreateAccount(ctx context.Context, req dto.Request[dto.CreateAccountDTO]) (res opt.Response)
+		UpdateAccountRole(ctx context.Context, req dto.Request[dto.UpdateAccountRoleDTO]) (res opt.Response)
+	}
+
+	IAuthException interface {
+		Login(key string) string
+		Refresh(key string) string
+		RevokeSessions(key string) string
+		CreateAccount(key string) string
+		UpdateAccountRole(key string) string
+	}
+
+	IAuthUsecase interface {
+		Login(ctx context.Context, req dto.Request[dto.LoginDTO]) opt.Response
+		Refresh(ctx context.Context, req dto.Request[dto.RefreshTokenDTO]) opt.Response
+		Logout(ctx context.Context, req dto.Request[dto.LogoutDTO]) opt.Response
+		Jwks(ctx context.Context) opt.Response
+	}
+
+	IAuthController interface {
+		Login(rw http.ResponseWriter, r *http.Request)
+		Refresh(rw http.ResponseWriter, r *http.Request)
+		Logout(rw http.ResponseWriter, r *http.Request)
+		Jwks(rw http.ResponseWriter, r *http.Request)
+	}
+)
@@ -0,0 +1,54 @@
+package inf
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/uptrace/bun"
+
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type (
+	IDeadLettersRepositorie interface {
+		Find() *bun.SelectQuery
+		FindOne() *bun.SelectQuery
+		Upsert(entitie entitie.DeadLettersEntitie) error
+		Update(entitie entitie.DeadLettersEntitie, status ...string) error
+	}
+
+	IDeadLettersService interface {
+		FindAllDeadLetters(ctx context.Context, req dto.Request[dto.ListDeadLettersDTO]) (res opt.Response)
+		FindDeadLetter(ctx context.Context, req dto.Request[dto.DeadLetterIDDTO]) (res opt.Response)
+		ReplayDeadLetter(ctx context.Context, req dto.Request[dto.DeadLetterIDDTO]) (res opt.Response)
+		ReplayAllDeadLetters(ctx context.Context, req dto.Request[dto.DeadLettersFilterDTO]) (res opt.Response)
+		DiscardDeadLetter(ctx context.Context, req dto.Request[dto.DeadLetterIDDTO]) (res opt.Response)
+		DiscardAllDeadLetters(ctx context.Context, req dto.Request[dto.DeadLettersFilterDTO]) (res opt.Response)
+	}
+
+	IDeadLettersException interface {
+		FindDeadLetter(key string) string
+		ReplayDeadLetter(key string) string
+		DiscardDeadLetter(key string) string
+	}
+
+	IDeadLettersUsecase interface {
+		FindAllDeadLetters(ctx context.Context, req dto.Request[dto.ListDeadLettersDTO]) opt.Response
+		FindDeadLetter(ctx context.Context, req dto.Request[dto.DeadLetterIDDTO]) opt.Response
+		ReplayDeadLetter(ctx context.Context, req dto.Request[dto.DeadLetterIDDTO]) opt.Response
+		ReplayAllDeadLetters(ctx context.Context, req dto.Request[dto.DeadLettersFilterDTO]) opt.Response
+		DiscardDeadLetter(ctx context.Context, req dto.Request[dto.DeadLetterIDDTO]) opt.Response
+		DiscardAllDeadLetters(ctx context.Context, req dto.Request[dto.DeadLettersFilterDTO]) opt.Response
+	}
+
+	IDeadLettersController interface {
+		FindAllDeadLetters(rw http.ResponseWriter, r *http.Request)
+		FindDeadLetter(rw http.ResponseWriter, r *http.Request)
+		ReplayDeadLetter(rw http.ResponseWriter, r *http.Request)
+		ReplayAllDeadLetters(rw http.ResponseWriter, r *http.Request)
+		DiscardDeadLetter(rw http.ResponseWriter, r *http.Request)
+		DiscardAllDeadLetters(rw http.ResponseWriter, r *http.Request)
+	}
+)
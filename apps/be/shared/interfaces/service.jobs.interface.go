@@ -0,0 +1,39 @@
+package inf
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type (
+	IJobsService interface {
+		FindAllJobs(ctx context.Context) (res opt.Response)
+		FindAllJobRuns(ctx context.Context) (res opt.Response)
+		TriggerJob(ctx context.Context, req dto.Request[dto.JobNameDTO]) (res opt.Response)
+		PauseJob(ctx context.Context, req dto.Request[dto.JobNameDTO]) (res opt.Response)
+		ResumeJob(ctx context.Context, req dto.Request[dto.JobNameDTO]) (res opt.Response)
+	}
+
+	IJobsException interface {
+		JobControl(key string) string
+	}
+
+	IJobsUsecase interface {
+		FindAllJobs(ctx context.Context) opt.Response
+		FindAllJobRuns(ctx context.Context) opt.Response
+		TriggerJob(ctx context.Context, req dto.Request[dto.JobNameDTO]) opt.Response
+		PauseJob(ctx context.Context, req dto.Request[dto.JobNameDTO]) opt.Response
+		ResumeJob(ctx context.Context, req dto.Request[dto.JobNameDTO]) opt.Response
+	}
+
+	IJobsController interface {
+		FindAllJobs(rw http.ResponseWriter, r *http.Request)
+		FindAllJobRuns(rw http.ResponseWriter, r *http.Request)
+		TriggerJob(rw http.ResponseWriter, r *http.Request)
+		PauseJob(rw http.ResponseWriter, r *http.Request)
+		ResumeJob(rw http.ResponseWriter, r *http.Request)
+	}
+)
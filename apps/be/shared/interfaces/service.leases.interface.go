@@ -0,0 +1,30 @@
+package inf
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type (
+	ILeasesService interface {
+		FindAllLeases(ctx context.Context) (res opt.Response)
+		FindLease(ctx context.Context, req dto.Request[dto.FindLeaseDTO]) (res opt.Response)
+	}
+
+	ILeasesException interface {
+		FindLease(key string) string
+	}
+
+	ILeasesUsecase interface {
+		FindAllLeases(ctx context.Context) opt.Response
+		FindLease(ctx context.Context, req dto.Request[dto.FindLeaseDTO]) opt.Response
+	}
+
+	ILeasesController interface {
+		FindAllLeases(rw http.ResponseWriter, r *http.Request)
+		FindLease(rw http.ResponseWriter, r *http.Request)
+	}
+)
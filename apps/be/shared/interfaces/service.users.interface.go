@@ -18,6 +18,8 @@ type (
 		FindOne() *bun.SelectQuery
 		Insert(entitie entitie.UsersEntitie, column string, dest ...any) error
 		Update(entitie entitie.UsersEntitie, column string, dest ...any) error
+		BulkUpdate(entitie entitie.UsersEntitie, ids ...string) error
+		BulkFencedUpdate(entitie entitie.UsersEntitie, token int64, ids ...string) error
 		Delete(id string, dest any) error
 	}
 
@@ -27,14 +29,16 @@ type (
 		FindOne(id string, filter *meilisearch.DocumentQuery) (*entitie.UsersDocument, error)
 		Insert(value any) error
 		Update(id string, value any) error
-		Delete(id string) error
+		Delete(id string, deletedAt int64) error
 		BulkInsert(value any) error
 		BulkUpdate(value any) error
+		BulkDelete(deletedAt map[string]int64) error
+		BulkDestroy(ids ...string) error
 		UpdateFilterableAttributes(attributes ...string) error
 		UpdateSearchableAttributes(attributes ...string) error
 		UpdateSortableAttributes(attributes ...string) error
 		UpdateDisplayedAttributes(attributes ...string) error
-		ListUsersDocuments(req dto.Request[dto.MeiliSearchDocumentsQuery]) (*opt.MeiliSearchDocuments[[]entitie.UsersDocument], error)
+		ListUsersDocuments(req dto.Request[dto.MeiliSearchDocumentsQuery], role string) (*opt.MeiliSearchDocuments[[]entitie.UsersDocument], error)
 	}
 
 	IUsersService interface {
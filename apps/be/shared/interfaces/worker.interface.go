@@ -1,5 +1,11 @@
 package inf
 
+import (
+	"time"
+
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+)
+
 type (
 	ISearchWorker interface {
 		SearchRun()
@@ -8,4 +14,18 @@ type (
 	IDeadLetterQueueWorker interface {
 		DeadLetterQueueRun()
 	}
+
+	IAuditWorker interface {
+		AuditRun()
+	}
+
+	IConsumerRegistry interface {
+		IJobControl
+		Consume(broker IBroker, name string, req dto.Request[dto.BrokerOptions], handler func(d dto.BrokerDelivery) dto.BrokerAction) error
+		Register(name, queue string) error
+		Throttle(name string, throttled bool) error
+		Backpressure(name string, backpressure IBackpressure) error
+		Handler(name string, handler func(d dto.BrokerDelivery) dto.BrokerAction) func(d dto.BrokerDelivery) dto.BrokerAction
+		Shutdown(timeout time.Duration) error
+	}
 )
@@ -1,10 +1,13 @@
 package opt
 
+import "net/netip"
+
 type (
 	Application struct {
-		ENV          string
-		PORT         string
-		INBOUND_SIZE int
+		ENV             string
+		PORT            string
+		INBOUND_SIZE    int
+		TRUSTED_PROXIES []netip.Prefix
 	}
 
 	Redis struct {
@@ -16,14 +19,63 @@ type (
 	}
 
 	Jwt struct {
-		SECRET  string
-		EXPIRED int
+		SECRET          string
+		EXPIRED         int
+		REFRESH_EXPIRED int
+		KEY_ROTATION    int
+		KEY_GRACE       int
+	}
+
+	Auth struct {
+		PUBLIC_ROUTES []string
+		MODE          string
+	}
+
+	Oidc struct {
+		JWKS_URL     string
+		JWKS_REFRESH int
+		ISSUER       string
+		AUDIENCE     string
+		LEEWAY       int
+		ROLE_CLAIM   string
+		ROLES        map[string]string
+		TENANT_CLAIM string
+	}
+
+	RateLimit struct {
+		RULES []RateLimitRule
+	}
+
+	RateLimitRule struct {
+		ROUTE    string
+		IDENTITY string
+		LIMIT    int
+		WINDOW   int
+	}
+
+	Audit struct {
+		HASH_KEY string
+	}
+
+	Broker struct {
+		DRIVER            string
+		SIGNING_KEY_ID    string
+		SIGNING_KEYS      map[string]string
+		SIGNATURE_TTL     int
+		ENCRYPTION_KEY_ID string
+		ENCRYPTION_KEYS   map[string]string
 	}
 
 	RabbitMQ struct {
-		URL    string
-		VSN    string
-		SECRET string
+		URL             string
+		VSN             string
+		SECRET          string
+		RETRY_MAX       int
+		RETRY_DELAY     int
+		POOL_SIZE       int
+		CONFIRM_TTL     int
+		PARTITIONS      int
+		TLS_SKIP_VERIFY bool
 	}
 
 	MeiliSearch struct {
@@ -31,12 +83,41 @@ type (
 		KEY string
 	}
 
+	Scheduler struct {
+		LEASE_TTL        int
+		DELETE_MODE      string
+		CRONTAB          map[string]string
+		PORT             string
+		BATCH_SIZE       int
+		SHUTDOWN_TIMEOUT int
+	}
+
+	Worker struct {
+		PORT                  string
+		DEDUP_TTL             int
+		VERSION_TTL           int
+		BATCH_SIZE            int
+		BATCH_WAIT            int
+		BACKPRESSURE_HIGH     int
+		BACKPRESSURE_LOW      int
+		BACKPRESSURE_INTERVAL int
+		CONSUMERS             int
+		SHUTDOWN_TIMEOUT      int
+	}
+
 	Environtment struct {
 		APP         Application
 		REDIS       Redis
 		POSTGRES    Postgres
 		JWT         Jwt
+		AUTH        Auth
+		OIDC        Oidc
+		RATE_LIMIT  RateLimit
+		AUDIT       Audit
+		BROKER      Broker
 		RABBITMQ    RabbitMQ
 		MEILISEARCH MeiliSearch
+		SCHEDULER   Scheduler
+		WORKER      Worker
 	}
 )
@@ -0,0 +1,14 @@
+package opt
+
+type (
+	BackpressureMetadata struct {
+		Name      string `json:"name"`
+		IsPaused  bool   `json:"is_paused"`
+		Backlog   int64  `json:"backlog"`
+		High      int64  `json:"high"`
+		Low       int64  `json:"low"`
+		Indexing  int    `json:"indexing"`
+		Documents int64  `json:"documents"`
+		CheckedAt string `json:"checked_at,omitempty"`
+	}
+)
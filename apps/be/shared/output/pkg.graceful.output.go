@@ -7,4 +7,5 @@ import (
 type Graceful struct {
 	HANDLER *chi.Mux
 	ENV     *Environtment
+	PORT    string
 }
@@ -2,6 +2,7 @@ package opt
 
 import (
 	"crypto/rsa"
+	"time"
 )
 
 type (
@@ -22,4 +23,18 @@ type (
 		Token   string `json:"token"`
 		Expired int    `json:"expired"`
 	}
+
+	VerifyMetadata struct {
+		Prefix    string         `json:"prefix"`
+		Jti       string         `json:"jti"`
+		ExpiredAt time.Time      `json:"expired_at"`
+		Claims    map[string]any `json:"claims"`
+	}
+
+	SigningKeyMetadata struct {
+		Kid        string          `json:"kid"`
+		PrivateKey *rsa.PrivateKey `json:"-"`
+		CreatedAt  time.Time       `json:"created_at"`
+		RetiredAt  time.Time       `json:"retired_at"`
+	}
 )
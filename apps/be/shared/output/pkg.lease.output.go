@@ -0,0 +1,12 @@
+package opt
+
+type (
+	LeaseMetadata struct {
+		Name      string `json:"name"`
+		Holder    string `json:"holder,omitempty"`
+		Token     int64  `json:"token,omitempty"`
+		ExpiredAt string `json:"expired_at,omitempty"`
+		TTL       int64  `json:"ttl"`
+		IsHeld    bool   `json:"is_held"`
+	}
+)
@@ -0,0 +1,11 @@
+package opt
+
+type (
+	RateLimitMetadata struct {
+		Allowed    bool `json:"allowed"`
+		Limit      int  `json:"limit"`
+		Remaining  int  `json:"remaining"`
+		RetryAfter int  `json:"retry_after"`
+		Reset      int  `json:"reset"`
+	}
+)
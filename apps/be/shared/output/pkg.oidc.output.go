@@ -0,0 +1,10 @@
+package opt
+
+type (
+	OidcMetadata struct {
+		Subject     string   `json:"subject"`
+		Role        string   `json:"role"`
+		Permissions []string `json:"permissions"`
+		TenantID    string   `json:"tenant_id"`
+	}
+)
@@ -0,0 +1,9 @@
+package opt
+
+type (
+	SessionMetadata struct {
+		Subject string        `json:"subject"`
+		Session string        `json:"session"`
+		Token   TokenMetadata `json:"token"`
+	}
+)
@@ -0,0 +1,30 @@
+package opt
+
+type (
+	JobMetadata struct {
+		Name        string   `json:"name"`
+		Kind        string   `json:"kind"`
+		Crontab     string   `json:"crontab,omitempty"`
+		Queue       string   `json:"queue,omitempty"`
+		Timeout     string   `json:"timeout,omitempty"`
+		Overlap     string   `json:"overlap,omitempty"`
+		Locks       []string `json:"locks,omitempty"`
+		IsLeader    bool     `json:"is_leader"`
+		IsPaused    bool     `json:"is_paused"`
+		IsThrottled bool     `json:"is_throttled,omitempty"`
+		Running     int      `json:"running"`
+		Skipped     int64    `json:"skipped,omitempty"`
+		NextRunAt   string   `json:"next_run_at,omitempty"`
+		LastRunAt   string   `json:"last_run_at,omitempty"`
+
+		Backpressure *BackpressureMetadata `json:"backpressure,omitempty"`
+	}
+
+	JobRunMetadata struct {
+		ID        string `json:"id"`
+		Name      string `json:"name"`
+		Trigger   string `json:"trigger"`
+		StartedAt string `json:"started_at"`
+		Duration  int64  `json:"duration"`
+	}
+)
@@ -0,0 +1,18 @@
+package opt
+
+import (
+	entitie "github.com/restuwahyu13/go-fast-search/domain/entities"
+)
+
+type (
+	ApiKeyMetadata struct {
+		entitie.ApiKeysEntitie
+		Key string `json:"key"`
+	}
+
+	ApiKeyPrincipal struct {
+		ID     string   `json:"id"`
+		Owner  string   `json:"owner"`
+		Scopes []string `json:"scopes"`
+	}
+)
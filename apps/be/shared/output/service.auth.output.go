@@ -0,0 +1,14 @@
+package opt
+
+type (
+	TokenMetadata struct {
+		AccessToken    string `json:"access_token"`
+		RefreshToken   string `json:"refresh_token"`
+		Expired        int    `json:"expired"`
+		RefreshExpired int    `json:"refresh_expired"`
+	}
+
+	SessionsAffected struct {
+		Total int `json:"total"`
+	}
+)
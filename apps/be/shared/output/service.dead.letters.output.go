@@ -0,0 +1,7 @@
+package opt
+
+type (
+	DeadLettersAffected struct {
+		Total int `json:"total"`
+	}
+)
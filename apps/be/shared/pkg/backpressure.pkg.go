@@ -0,0 +1,164 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	search "github.com/meilisearch/meilisearch-go"
+	goredis "github.com/redis/go-redis/v9"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type backpressure struct {
+	ctx         context.Context
+	meilisearch search.ServiceManager
+	redis       *goredis.Client
+	name        string
+	high        int64
+	low         int64
+	interval    time.Duration
+	paused      *atomic.Bool
+	metrics     *atomic.Pointer[opt.BackpressureMetadata]
+}
+
+/**
+* Watch the meilisearch task queue, consumer is paused once the enqueued and processing task pass
+* the high threshold and resumed when the backlog drain below the low threshold
+ */
+func NewBackpressure(ctx context.Context, con search.ServiceManager, rds *goredis.Client, options dto.BackpressureOptions) inf.IBackpressure {
+	if options.High < 1 {
+		options.High = cons.BACKPRESSURE_HIGH
+	}
+
+	if options.Low < 1 || options.Low >= options.High {
+		options.Low = options.High / 10
+	}
+
+	if options.Interval <= 0 {
+		options.Interval = time.Duration(time.Second * cons.BACKPRESSURE_INTERVAL)
+	}
+
+	return backpressure{
+		ctx:         ctx,
+		meilisearch: con,
+		redis:       rds,
+		name:        options.Name,
+		high:        options.High,
+		low:         options.Low,
+		interval:    options.Interval,
+		paused:      new(atomic.Bool),
+		metrics:     new(atomic.Pointer[opt.BackpressureMetadata]),
+	}
+}
+
+func (p backpressure) Run(ctx context.Context, handler func(paused bool)) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.check(handler)
+
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p backpressure) IsPaused() bool {
+	return p.paused.Load()
+}
+
+/**
+* Metrics return the last measured backlog with the thresholds, the backlog is zero until the first check
+ */
+func (p backpressure) Metrics() opt.BackpressureMetadata {
+	if metrics := p.metrics.Load(); metrics != nil {
+		return *metrics
+	}
+
+	return opt.BackpressureMetadata{Name: p.name, IsPaused: p.paused.Load(), High: p.high, Low: p.low}
+}
+
+func (p backpressure) check(handler func(paused bool)) {
+	tasks, err := p.meilisearch.GetTasksWithContext(p.ctx, &search.TasksQuery{
+		Statuses: []search.TaskStatus{search.TaskStatusEnqueued, search.TaskStatusProcessing},
+		Limit:    1,
+	})
+
+	if err != nil {
+		Logrus(cons.ERROR, err)
+		return
+	}
+
+	stats, err := p.meilisearch.GetStatsWithContext(p.ctx)
+	if err != nil {
+		Logrus(cons.ERROR, err)
+		return
+	}
+
+	indexing := 0
+	documents := int64(0)
+
+	for _, index := range stats.Indexes {
+		if index.IsIndexing {
+			indexing++
+		}
+
+		documents += index.NumberOfDocuments
+	}
+
+	switch {
+
+	case tasks.Total >= p.high && p.paused.CompareAndSwap(false, true):
+		Logrus(cons.INFO, "Meilisearch backlog %d task reach %d, consumer %s is paused", tasks.Total, p.high, p.name)
+
+		p.incr(cons.BACKPRESSURE_PAUSE)
+		handler(true)
+
+	case tasks.Total <= p.low && p.paused.CompareAndSwap(true, false):
+		Logrus(cons.INFO, "Meilisearch backlog %d task drain to %d, consumer %s is resumed", tasks.Total, p.low, p.name)
+
+		p.incr(cons.BACKPRESSURE_RESUME)
+		handler(false)
+	}
+
+	checkedAt := time.Now()
+
+	p.metrics.Store(&opt.BackpressureMetadata{
+		Name:      p.name,
+		IsPaused:  p.paused.Load(),
+		Backlog:   tasks.Total,
+		High:      p.high,
+		Low:       p.low,
+		Indexing:  indexing,
+		Documents: documents,
+		CheckedAt: checkedAt.Format(time.RFC3339),
+	})
+
+	metrics := map[string]any{
+		"paused":     p.paused.Load(),
+		"backlog":    tasks.Total,
+		"indexing":   indexing,
+		"documents":  documents,
+		"checked_at": checkedAt.Unix(),
+	}
+
+	if err := p.redis.HSet(p.ctx, fmt.Sprintf(cons.BACKPRESSURE_METRICS_KEY, p.name), metrics).Err(); err != nil {
+		Logrus(cons.ERROR, err)
+	}
+}
+
+func (p backpressure) incr(kind string) {
+	if err := p.redis.HIncrBy(p.ctx, fmt.Sprintf(cons.BACKPRESSURE_METRICS_KEY, p.name), kind, 1).Err(); err != nil {
+		Logrus(cons.ERROR, err)
+	}
+}
@@ -0,0 +1,264 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	search "github.com/meilisearch/meilisearch-go"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type (
+	batch struct {
+		doc     string
+		action  string
+		items   []map[string]any
+		index   map[string]int
+		waiters []chan error
+		timer   *time.Timer
+	}
+
+	batcher struct {
+		ctx         context.Context
+		meilisearch inf.IMeiliSearch
+		size        int
+		wait        time.Duration
+		mutex       *sync.Mutex
+		batches     map[string]*batch
+		queue       chan *batch
+	}
+)
+
+/**
+* Collect search document per index and action, the batch is written with one meilisearch call
+* when it reach the size or the wait time and every message of the batch get the same task outcome
+ */
+func NewBatcher(ctx context.Context, con search.ServiceManager, options dto.BatcherOptions) inf.IBatcher {
+	if options.Size < 1 {
+		options.Size = cons.MEILISEARCH_BATCH_SIZE
+	}
+
+	if options.Wait <= 0 {
+		options.Wait = time.Duration(time.Millisecond * cons.MEILISEARCH_BATCH_WAIT)
+	}
+
+	p := batcher{
+		ctx:         ctx,
+		meilisearch: NewMeiliSearch(ctx, con),
+		size:        options.Size,
+		wait:        options.Wait,
+		mutex:       new(sync.Mutex),
+		batches:     make(map[string]*batch),
+		queue:       make(chan *batch, cons.MEILISEARCH_BATCH_QUEUE),
+	}
+
+	go p.run()
+
+	return p
+}
+
+func (p batcher) key(doc, action string) string {
+	return fmt.Sprintf("%s:%s", doc, action)
+}
+
+/**
+* Add return a channel receiving the outcome of the batch, add is called in the order the events
+* are applied so repeated write of the same id are merged in the same order
+ */
+func (p batcher) Add(doc, action, id string, data map[string]any) <-chan error {
+	res := make(chan error, 1)
+
+	if action != cons.INSERT && action != cons.UPDATE && action != cons.DELETE {
+		res <- cons.MEILISEARCH_UNKNOWN_ACTION
+		return res
+	}
+
+	if id == "" {
+		res <- cons.MEILISEARCH_MISSING_ID
+		return res
+	}
+
+	document := maps.Clone(data)
+	if action == cons.DELETE {
+		deletedAt := helper.DeletedAt(data)
+		if deletedAt == 0 {
+			res <- cons.MEILISEARCH_MISSING_DELETE
+			return res
+		}
+
+		document = map[string]any{"deleted_at": deletedAt}
+	}
+
+	if document == nil {
+		document = make(map[string]any)
+	}
+
+	document["id"] = id
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	/**
+	* Pending write of the same id with another action is flushed first, meilisearch process
+	* the task of an index in order so the document is not overwritten by an older write
+	 */
+	for _, other := range []string{cons.INSERT, cons.UPDATE, cons.DELETE} {
+		if other == action {
+			continue
+		}
+
+		if pending, ok := p.batches[p.key(doc, other)]; ok {
+			if _, ok := pending.index[id]; ok {
+				p.flush(pending)
+			}
+		}
+	}
+
+	current, ok := p.batches[p.key(doc, action)]
+	if !ok {
+		current = &batch{doc: doc, action: action, index: make(map[string]int)}
+		current.timer = time.AfterFunc(p.wait, func() {
+			p.mutex.Lock()
+			defer p.mutex.Unlock()
+
+			p.flush(current)
+		})
+
+		p.batches[p.key(doc, action)] = current
+	}
+
+	if i, ok := current.index[id]; ok && action == cons.INSERT {
+		current.items[i] = document
+	} else if ok {
+		maps.Copy(current.items[i], document)
+	} else {
+		current.index[id] = len(current.items)
+		current.items = append(current.items, document)
+	}
+
+	current.waiters = append(current.waiters, res)
+
+	if len(current.waiters) >= p.size {
+		p.flush(current)
+	}
+
+	return res
+}
+
+/**
+* Flush must be called with the mutex held, the batch is only taken out and queued so the
+* meilisearch call run without the mutex and the batches are still written in the order they are flushed
+ */
+func (p batcher) flush(current *batch) {
+	key := p.key(current.doc, current.action)
+
+	if p.batches[key] != current {
+		return
+	}
+
+	delete(p.batches, key)
+	current.timer.Stop()
+
+	p.queue <- current
+}
+
+/**
+* Run write the flushed batches one by one, the task is enqueued in flush order and only the wait
+* for the task outcome run in background
+ */
+func (p batcher) run() {
+	last := make(map[string]*search.TaskInfo)
+
+	for current := range p.queue {
+		if err := p.ctx.Err(); err != nil {
+			p.notify(current, err)
+			continue
+		}
+
+		var (
+			task *search.TaskInfo
+			err  error
+		)
+
+		switch current.action {
+		case cons.INSERT:
+			task, err = p.meilisearch.Insert(current.doc, &current.items)
+
+		case cons.UPDATE:
+			task, err = p.meilisearch.Upsert(current.doc, &current.items)
+
+		case cons.DELETE:
+			task, err = p.tombstone(current, last[current.doc])
+		}
+
+		if err != nil {
+			p.notify(current, err)
+			continue
+		}
+
+		if task == nil {
+			p.notify(current, nil)
+			continue
+		}
+
+		last[current.doc] = task
+
+		go func() {
+			err := p.meilisearch.Wait(task)
+			if err == nil {
+				Logrus(cons.INFO, "Success %s %d document of %d message to: %s", current.action, len(current.items), len(current.waiters), current.doc)
+			}
+
+			p.notify(current, err)
+		}()
+	}
+}
+
+/**
+* Tombstone only the documents that exist, delete of a missing id is a no op. The previous task of
+* the index is awaited first so a document inserted by an earlier batch is found, its outcome is
+* reported to its own batch
+ */
+func (p batcher) tombstone(current *batch, previous *search.TaskInfo) (*search.TaskInfo, error) {
+	if previous != nil {
+		_ = p.meilisearch.Wait(previous)
+	}
+
+	ids := make([]string, 0, len(current.index))
+	for id := range current.index {
+		ids = append(ids, id)
+	}
+
+	resDocs := new(search.DocumentsResult)
+
+	filter := &search.DocumentsQuery{Filter: helper.FilterIn("id", ids...), Fields: []string{"id"}, Limit: int64(len(ids))}
+	if err := p.meilisearch.Find(current.doc, filter, resDocs); err != nil {
+		return nil, err
+	}
+
+	items := make([]map[string]any, 0, len(resDocs.Results))
+	for _, resDoc := range resDocs.Results {
+		if i, ok := current.index[fmt.Sprint(resDoc["id"])]; ok {
+			items = append(items, current.items[i])
+		}
+	}
+
+	if len(items) < 1 {
+		return nil, nil
+	}
+
+	return p.meilisearch.Upsert(current.doc, &items)
+}
+
+func (p batcher) notify(current *batch, err error) {
+	for _, waiter := range current.waiters {
+		waiter <- err
+	}
+}
@@ -0,0 +1,103 @@
+package pkg
+
+import (
+	"context"
+	"maps"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+/**
+* In process queues keyed by queue name, shared by every memory broker of the process
+ */
+var memoryQueues sync.Map
+
+type memoryBroker struct {
+	ctx   context.Context
+	retry inf.IRetry
+}
+
+func NewMemoryBroker(ctx context.Context, env dto.Request[dto.Environtment]) inf.IBroker {
+	return memoryBroker{ctx: ctx, retry: NewRetry(env)}
+}
+
+func (p memoryBroker) queue(name string) chan dto.BrokerDelivery {
+	queue, _ := memoryQueues.LoadOrStore(name, make(chan dto.BrokerDelivery, cons.BROKER_MEMORY_BUFFER))
+	return queue.(chan dto.BrokerDelivery)
+}
+
+func (p memoryBroker) Publisher(req dto.Request[dto.BrokerOptions]) error {
+	parser := helper.NewParser()
+
+	bodyByte, err := parser.Marshal(&req.Option.Body)
+	if err != nil {
+		return err
+	}
+
+	delivery := dto.BrokerDelivery{ID: uuid.NewString(), Headers: maps.Clone(req.Option.Args), Body: bodyByte}
+
+	select {
+	case p.queue(req.Option.QueueName) <- delivery:
+		Logrus(cons.INFO, "Success message delivery to: %s", req.Option.QueueName)
+		return nil
+
+	case <-p.ctx.Done():
+		return p.ctx.Err()
+	}
+}
+
+func (p memoryBroker) Consumer(req dto.Request[dto.BrokerOptions], callback func(d dto.BrokerDelivery) (action dto.BrokerAction)) func() {
+	if req.Option.Concurrency < 1 {
+		req.Option.Concurrency = max(runtime.NumCPU()/2, 1)
+	}
+
+	queue := p.queue(req.Option.QueueName)
+	ctx, stop := context.WithCancel(p.ctx)
+
+	for range req.Option.Concurrency {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+
+				case delivery := <-queue:
+					settle := func(action dto.BrokerAction) {
+						if action == cons.BROKER_NACK_REQUEUE {
+							go func() { queue <- delivery }()
+						}
+					}
+
+					message := delivery
+					message.Settle = settle
+
+					if action := callback(message); action != cons.BROKER_DEFER {
+						settle(action)
+					}
+				}
+			}
+		}()
+	}
+
+	return stop
+}
+
+func (p memoryBroker) Delay(req dto.Request[dto.BrokerOptions], attempt int) error {
+	delay := p.retry.Delay(attempt)
+
+	time.AfterFunc(delay, func() {
+		if err := p.Publisher(req); err != nil {
+			Logrus(cons.ERROR, err)
+		}
+	})
+
+	return nil
+}
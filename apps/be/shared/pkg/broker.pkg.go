@@ -0,0 +1,70 @@
+package pkg
+
+import (
+	"context"
+
+	goredis "github.com/redis/go-redis/v9"
+	amqp "github.com/wagslane/go-rabbitmq"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type signedBroker struct {
+	broker inf.IBroker
+	signer inf.ISigner
+	retry  inf.IRetry
+}
+
+/**
+* Broker backend is selected by BROKER_DRIVER, rabbitmq is the default, redis use redis streams
+* with consumer groups and memory only deliver message inside the same process
+ */
+func NewBroker(ctx context.Context, env dto.Request[dto.Environtment], amqp *amqp.Conn, rds *goredis.Client) inf.IBroker {
+	var broker inf.IBroker
+
+	switch env.Config.BROKER.DRIVER {
+
+	case cons.BROKER_DRIVER_REDIS:
+		broker = NewRedisBroker(ctx, rds, env)
+
+	case cons.BROKER_DRIVER_MEMORY:
+		broker = NewMemoryBroker(ctx, env)
+
+	default:
+		broker = NewRabbitMQ(ctx, amqp, env)
+	}
+
+	return signedBroker{broker: broker, signer: NewSigner(env), retry: NewRetry(env)}
+}
+
+func (p signedBroker) Publisher(req dto.Request[dto.BrokerOptions]) error {
+	if err := p.signer.Sign(&req, 0); err != nil {
+		return err
+	}
+
+	return p.broker.Publisher(req)
+}
+
+/**
+* Unsigned, tampered or expired message is discarded before it reach the consumer callback
+ */
+func (p signedBroker) Consumer(req dto.Request[dto.BrokerOptions], callback func(d dto.BrokerDelivery) (action dto.BrokerAction)) func() {
+	return p.broker.Consumer(req, func(d dto.BrokerDelivery) dto.BrokerAction {
+		if err := p.signer.Verify(d); err != nil {
+			Logrus(cons.ERROR, "Message %s from queue %s is rejected: %v", d.ID, req.Option.QueueName, err)
+			return cons.BROKER_NACK_DISCARD
+		}
+
+		return callback(d)
+	})
+}
+
+func (p signedBroker) Delay(req dto.Request[dto.BrokerOptions], attempt int) error {
+	if err := p.signer.Sign(&req, p.retry.Delay(attempt)); err != nil {
+		return err
+	}
+
+	return p.broker.Delay(req, attempt)
+}
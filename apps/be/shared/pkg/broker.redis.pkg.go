@@ -0,0 +1,484 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lithammer/shortuuid"
+	goredis "github.com/redis/go-redis/v9"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+/**
+* Delayed message poller started by this process, keyed by the redis client
+ */
+var redisSchedulers sync.Map
+
+/**
+* KEYS[1] = delayed key, KEYS[2] = stream key
+* ARGV[1] = member, ARGV[2...] = stream field and value pairs
+ */
+var brokerScheduleScript = goredis.NewScript(`
+if redis.call('ZREM', KEYS[1], ARGV[1]) == 1 then
+	redis.call('XADD', KEYS[2], '*', unpack(ARGV, 2))
+	return 1
+end
+
+return 0
+`)
+
+type redisBroker struct {
+	ctx   context.Context
+	redis *goredis.Client
+	retry inf.IRetry
+}
+
+/**
+* Every queue is a redis stream with one consumer group named after the queue, delayed message wait
+* in a sorted set scored by the due time until the poller move it back to the stream
+ */
+func NewRedisBroker(ctx context.Context, con *goredis.Client, env dto.Request[dto.Environtment]) inf.IBroker {
+	return redisBroker{ctx: ctx, redis: con, retry: NewRetry(env)}
+}
+
+func (p redisBroker) stream(queue string) string {
+	return fmt.Sprintf(cons.BROKER_STREAM_KEY, queue)
+}
+
+func (p redisBroker) values(req dto.Request[dto.BrokerOptions]) (map[string]any, error) {
+	parser := helper.NewParser()
+
+	bodyByte, err := parser.Marshal(&req.Option.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	headersByte, err := parser.Marshal(&req.Option.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{"queue": req.Option.QueueName, "body": string(bodyByte), "headers": string(headersByte)}, nil
+}
+
+func (p redisBroker) Publisher(req dto.Request[dto.BrokerOptions]) error {
+	if p.redis == nil {
+		return cons.BROKER_NOT_CONNECTED
+	}
+
+	values, err := p.values(req)
+	if err != nil {
+		return err
+	}
+
+	if err := p.redis.XAdd(p.ctx, &goredis.XAddArgs{Stream: p.stream(req.Option.QueueName), Values: values}).Err(); err != nil {
+		return err
+	}
+
+	Logrus(cons.INFO, "Success message delivery to: %s", req.Option.QueueName)
+	return nil
+}
+
+/**
+* Consumer is started in background like the rabbitmq consumer, single active consumer is emulated
+* with a lease per queue so only the lease holder read the stream. Stop end the reads and the lease is
+* released once every read of the consumer is settled, so no other instance read while a delivery is in flight
+ */
+func (p redisBroker) Consumer(req dto.Request[dto.BrokerOptions], callback func(d dto.BrokerDelivery) (action dto.BrokerAction)) func() {
+	if p.redis == nil {
+		Logrus(cons.ERROR, cons.BROKER_NOT_CONNECTED)
+		return func() {}
+	}
+
+	if req.Option.ConsumerID == "" {
+		hostname, _ := os.Hostname()
+		req.Option.ConsumerID = fmt.Sprintf("%s:%d:%s", hostname, os.Getpid(), shortuuid.New())
+	}
+
+	if req.Option.Concurrency < 1 {
+		req.Option.Concurrency = max(runtime.NumCPU()/2, 1)
+	}
+
+	if req.Option.Prefetch < 1 {
+		req.Option.Prefetch = 5
+	}
+
+	if err := p.group(req.Option.QueueName); err != nil {
+		Logrus(cons.ERROR, err)
+		return func() {}
+	}
+
+	ctx, stop := context.WithCancel(p.ctx)
+
+	var lease inf.ILease
+
+	leaseCtx, release := context.WithCancel(p.ctx)
+	consumers := new(sync.WaitGroup)
+
+	if singleActive, _ := req.Option.Args[cons.X_SINGLE_ACTIVE_CONSUMER].(bool); singleActive {
+		req.Option.Concurrency = 1
+
+		lease = NewLease(p.ctx, p.redis, dto.LeaseOptions{Name: fmt.Sprintf(cons.BROKER_CONSUMER_LEASE, req.Option.QueueName)})
+		go lease.Run(leaseCtx)
+	}
+
+	if _, loaded := redisSchedulers.LoadOrStore(p.redis, true); !loaded {
+		go p.schedule()
+	}
+
+	for i := range req.Option.Concurrency {
+		consumers.Add(1)
+
+		go func() {
+			defer consumers.Done()
+			p.consume(ctx, req, fmt.Sprintf("%s:%d", req.Option.ConsumerID, i), lease, callback)
+		}()
+	}
+
+	go func() {
+		consumers.Wait()
+		release()
+	}()
+
+	return stop
+}
+
+func (p redisBroker) group(queue string) error {
+	err := p.redis.XGroupCreateMkStream(p.ctx, p.stream(queue), queue, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+
+	return nil
+}
+
+func (p redisBroker) consume(ctx context.Context, req dto.Request[dto.BrokerOptions], consumer string, lease inf.ILease, callback func(d dto.BrokerDelivery) dto.BrokerAction) {
+	stream := p.stream(req.Option.QueueName)
+	claimedAt := time.Time{}
+	pending, leader := false, false
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		default:
+		}
+
+		if lease != nil && !lease.IsLeader() {
+			leader = false
+			time.Sleep(time.Second)
+			continue
+		}
+
+		/**
+		* New leader take over every pending message of the group before reading new message, so the message
+		* left by the previous leader are delivered first and in stream order however young they are
+		 */
+		if lease != nil && !leader {
+			if err := p.claim(ctx, stream, req.Option.QueueName, consumer); err != nil {
+				Logrus(cons.ERROR, err)
+				time.Sleep(time.Second)
+				continue
+			}
+
+			pending, leader = true, true
+		}
+
+		messages := []goredis.XMessage{}
+
+		/**
+		* Message left pending by a dead consumer is claimed back once it is idle long enough
+		 */
+		if lease == nil && time.Since(claimedAt) > time.Duration(time.Second*30) {
+			claimedAt = time.Now()
+
+			claimed, _, err := p.redis.XAutoClaim(ctx, &goredis.XAutoClaimArgs{
+				Stream:   stream,
+				Group:    req.Option.QueueName,
+				Consumer: consumer,
+				MinIdle:  time.Duration(time.Minute),
+				Start:    "0-0",
+				Count:    int64(req.Option.Prefetch),
+			}).Result()
+
+			if err != nil {
+				Logrus(cons.ERROR, err)
+			}
+
+			messages = claimed
+		}
+
+		if len(messages) < 1 {
+			id := ">"
+			block := time.Duration(time.Second * 5)
+
+			// pending message of this consumer is read from the start of its pending list and is not blocked on
+			if pending {
+				id, block = "0", -1
+			}
+
+			streams, err := p.redis.XReadGroup(ctx, &goredis.XReadGroupArgs{
+				Group:    req.Option.QueueName,
+				Consumer: consumer,
+				Streams:  []string{stream, id},
+				Count:    int64(req.Option.Prefetch),
+				Block:    block,
+			}).Result()
+
+			if err == goredis.Nil {
+				continue
+			}
+
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+
+				if strings.HasPrefix(err.Error(), "NOGROUP") {
+					err = p.group(req.Option.QueueName)
+				}
+
+				if err != nil {
+					Logrus(cons.ERROR, err)
+				}
+
+				time.Sleep(time.Second)
+				continue
+			}
+
+			for _, res := range streams {
+				messages = append(messages, res.Messages...)
+			}
+
+			if pending && len(messages) < 1 {
+				pending = false
+				continue
+			}
+		}
+
+		wg := new(sync.WaitGroup)
+		requeued := new(atomic.Bool)
+
+		/**
+		* Single active consumer queue is ordered, message read together are delivered one by one in stream
+		* order, requeued message stay pending in place and the next read start again from the pending list
+		 */
+		if lease != nil {
+			for _, message := range messages {
+				wg.Add(1)
+
+				if p.deliver(req.Option.QueueName, message, true, wg, requeued, callback) == cons.BROKER_NACK_REQUEUE {
+					break
+				}
+			}
+		}
+
+		/**
+		* Prefetch is the number of message in flight like rabbitmq, message read together are
+		* delivered together and the next read wait until all of them are settled
+		 */
+		if lease == nil {
+			for _, message := range messages {
+				wg.Add(1)
+				go p.deliver(req.Option.QueueName, message, false, wg, requeued, callback)
+			}
+		}
+
+		wg.Wait()
+
+		if requeued.Load() {
+			pending = true
+			time.Sleep(time.Second)
+		}
+	}
+}
+
+func (p redisBroker) claim(ctx context.Context, stream, group, consumer string) error {
+	start := "0-0"
+
+	for {
+		_, next, err := p.redis.XAutoClaimJustID(ctx, &goredis.XAutoClaimArgs{
+			Stream:   stream,
+			Group:    group,
+			Consumer: consumer,
+			MinIdle:  0,
+			Start:    start,
+			Count:    100,
+		}).Result()
+
+		if err != nil {
+			return err
+		}
+
+		if next == "0-0" {
+			return nil
+		}
+
+		start = next
+	}
+}
+
+/**
+* Deliver settle the message once the callback return, or later through the delivery Settle when the callback
+* defer it, the read is held until every message of it is settled so prefetch still bound the message in flight
+ */
+func (p redisBroker) deliver(queue string, message goredis.XMessage, inPlace bool, wg *sync.WaitGroup, requeued *atomic.Bool, callback func(d dto.BrokerDelivery) dto.BrokerAction) dto.BrokerAction {
+	parser := helper.NewParser()
+	once := new(sync.Once)
+
+	delivery := dto.BrokerDelivery{ID: message.ID, Headers: make(map[string]any)}
+	delivery.Settle = func(action dto.BrokerAction) {
+		once.Do(func() {
+			defer wg.Done()
+
+			if p.settle(queue, message, inPlace, action) {
+				requeued.Store(true)
+			}
+		})
+	}
+
+	if body, ok := message.Values["body"].(string); ok {
+		delivery.Body = []byte(body)
+	}
+
+	if headers, ok := message.Values["headers"].(string); ok {
+		if err := parser.Unmarshal([]byte(headers), &delivery.Headers); err != nil {
+			Logrus(cons.ERROR, err)
+		}
+	}
+
+	action := callback(delivery)
+	if action != cons.BROKER_DEFER {
+		delivery.Settle(action)
+	}
+
+	return action
+}
+
+/**
+* Requeued delivery of an ordered queue or of a stopped consumer stay pending, it is read again in order
+* by the same consumer or claimed back by another one, other delivery is settled even when the consumer
+* is stopped while the callback was running, settle report whether the message is left pending
+ */
+func (p redisBroker) settle(queue string, message goredis.XMessage, inPlace bool, action dto.BrokerAction) bool {
+	stream := p.stream(queue)
+
+	if action == cons.BROKER_NACK_REQUEUE && (inPlace || p.ctx.Err() != nil) {
+		return true
+	}
+
+	ctx := context.WithoutCancel(p.ctx)
+	pipe := p.redis.TxPipeline()
+
+	if action == cons.BROKER_NACK_REQUEUE {
+		pipe.XAdd(ctx, &goredis.XAddArgs{Stream: stream, Values: message.Values})
+	}
+
+	pipe.XAck(ctx, stream, queue, message.ID)
+	pipe.XDel(ctx, stream, message.ID)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		Logrus(cons.ERROR, err)
+	}
+
+	return false
+}
+
+func (p redisBroker) Delay(req dto.Request[dto.BrokerOptions], attempt int) error {
+	if p.redis == nil {
+		return cons.BROKER_NOT_CONNECTED
+	}
+
+	parser := helper.NewParser()
+
+	values, err := p.values(req)
+	if err != nil {
+		return err
+	}
+
+	values["id"] = uuid.NewString()
+
+	member, err := parser.Marshal(&values)
+	if err != nil {
+		return err
+	}
+
+	delay, err := strconv.ParseInt(p.retry.Expiration(attempt), 10, 64)
+	if err != nil {
+		return err
+	}
+
+	score := float64(time.Now().Add(time.Duration(time.Millisecond * time.Duration(delay))).UnixMilli())
+	return p.redis.ZAdd(p.ctx, cons.BROKER_DELAYED_KEY, goredis.Z{Score: score, Member: string(member)}).Err()
+}
+
+/**
+* Move due delayed message back to its stream, ZREM and XADD run in one script so the message is published
+* once even when many poller run at the same time and is never lost between the two commands
+ */
+func (p redisBroker) schedule() {
+	parser := helper.NewParser()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			redisSchedulers.Delete(p.redis)
+			return
+
+		case <-ticker.C:
+		}
+
+		members, err := p.redis.ZRangeByScore(p.ctx, cons.BROKER_DELAYED_KEY, &goredis.ZRangeBy{
+			Min:   "-inf",
+			Max:   strconv.FormatInt(time.Now().UnixMilli(), 10),
+			Count: 100,
+		}).Result()
+
+		if err != nil {
+			Logrus(cons.ERROR, err)
+			continue
+		}
+
+		for _, member := range members {
+			values := make(map[string]any)
+
+			if err := parser.Unmarshal([]byte(member), &values); err != nil {
+				Logrus(cons.ERROR, err)
+
+				if err := p.redis.ZRem(p.ctx, cons.BROKER_DELAYED_KEY, member).Err(); err != nil {
+					Logrus(cons.ERROR, err)
+				}
+
+				continue
+			}
+
+			queue, _ := values["queue"].(string)
+			delete(values, "id")
+
+			args := []any{member}
+			for field, value := range values {
+				args = append(args, field, value)
+			}
+
+			if err := brokerScheduleScript.Run(p.ctx, p.redis, []string{cons.BROKER_DELAYED_KEY, p.stream(queue)}, args...).Err(); err != nil {
+				Logrus(cons.ERROR, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package pkg
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	gpc "github.com/restuwahyu13/go-playground-converter"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type eventRegistry struct {
+	mutex   *sync.RWMutex
+	schemas map[string]dto.EventSchema
+}
+
+/**
+* Event registry hold payload schema per event type, every schema is registered with the current version,
+* older versions are lifted to the current version through upcasters chained one version at a time
+ */
+func NewEventRegistry() inf.IEventRegistry {
+	registry := eventRegistry{mutex: new(sync.RWMutex), schemas: make(map[string]dto.EventSchema)}
+	registry.Register(searchDocumentSchema())
+	registry.Register(auditLogSchema())
+
+	return registry
+}
+
+func (p eventRegistry) Register(schema dto.EventSchema) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if schema.Type == "" || schema.Version < 1 {
+		return cons.EVENT_INVALID
+	}
+
+	p.schemas[schema.Type] = schema
+	return nil
+}
+
+func (p eventRegistry) Decode(body []byte) (*dto.Event[map[string]any], error) {
+	parser := helper.NewParser()
+
+	raw := make(map[string]any)
+	if err := parser.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	event := new(dto.Event[map[string]any])
+
+	if _, ok := raw["specversion"]; ok {
+		if err := parser.Unmarshal(body, event); err != nil {
+			return nil, err
+		}
+
+		errors, err := gpc.Validator(*event)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", cons.EVENT_INVALID, err)
+		}
+
+		if errors != nil {
+			return nil, fmt.Errorf("%w: %v", cons.EVENT_INVALID, errors.Errors)
+		}
+
+	} else {
+		schema, ok := p.legacy()
+		if !ok {
+			return nil, cons.EVENT_UNKNOWN_TYPE
+		}
+
+		// legacy message carry no id, derive it from the body so a redelivered message keep the same id for dedup
+		event.ID = uuid.NewSHA1(uuid.NameSpaceOID, body).String()
+		event.Type = schema.Type
+		event.Source = cons.EVENT_SOURCE_LEGACY
+		event.SpecVersion = cons.EVENT_SPEC_VERSION
+		event.DataVersion = 0
+		event.Time = time.Now()
+		event.Data = raw
+	}
+
+	if event.CorrelationID == "" {
+		event.CorrelationID = event.ID
+	}
+
+	p.mutex.RLock()
+	schema, ok := p.schemas[event.Type]
+	p.mutex.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cons.EVENT_UNKNOWN_TYPE, event.Type)
+	}
+
+	if event.DataVersion > schema.Version {
+		return nil, fmt.Errorf("%w: %s version %d", cons.EVENT_UNSUPPORTED_VERSION, event.Type, event.DataVersion)
+	}
+
+	for event.DataVersion < schema.Version {
+		upcaster, ok := schema.Upcasters[event.DataVersion]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s version %d", cons.EVENT_UNSUPPORTED_VERSION, event.Type, event.DataVersion)
+		}
+
+		data, err := upcaster(event.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		event.Data = data
+		event.DataVersion++
+	}
+
+	if schema.Validate != nil {
+		if err := schema.Validate(event.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	return event, nil
+}
+
+func (p eventRegistry) legacy() (dto.EventSchema, bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	for _, schema := range p.schemas {
+		if schema.Legacy {
+			return schema, true
+		}
+	}
+
+	return dto.EventSchema{}, false
+}
+
+/**
+* Search document schema, version 0 is the bare message published before the envelope existed,
+* it carry the same payload shape as version 1, data is validated as any since encrypted data is a string
+ */
+func searchDocumentSchema() dto.EventSchema {
+	return dto.EventSchema{
+		Type:    cons.EVENT_TYPE_SEARCH_DOCUMENT,
+		Version: cons.EVENT_VERSION_SEARCH_DOCUMENT,
+		Legacy:  true,
+		Upcasters: map[int]func(data map[string]any) (map[string]any, error){
+			0: func(data map[string]any) (map[string]any, error) {
+				return data, nil
+			},
+		},
+		Validate: func(data map[string]any) error {
+			transform := helper.NewTransform()
+
+			doc := dto.MeiliSearchDocuments[any]{}
+			if err := transform.SrcToDest(data, &doc); err != nil {
+				return fmt.Errorf("%w: %v", cons.EVENT_INVALID, err)
+			}
+
+			errors, err := gpc.Validator(doc)
+			if err != nil {
+				return fmt.Errorf("%w: %v", cons.EVENT_INVALID, err)
+			}
+
+			if errors != nil {
+				return fmt.Errorf("%w: %v", cons.EVENT_INVALID, errors.Errors)
+			}
+
+			return nil
+		},
+	}
+}
+
+// Audit log schema, the event is only published with the envelope so it has no legacy version
+func auditLogSchema() dto.EventSchema {
+	return dto.EventSchema{
+		Type:    cons.EVENT_TYPE_AUDIT_LOG,
+		Version: cons.EVENT_VERSION_AUDIT_LOG,
+		Validate: func(data map[string]any) error {
+			transform := helper.NewTransform()
+
+			auditLog := dto.AuditLog{}
+			if err := transform.SrcToDest(data, &auditLog); err != nil {
+				return fmt.Errorf("%w: %v", cons.EVENT_INVALID, err)
+			}
+
+			errors, err := gpc.Validator(auditLog)
+			if err != nil {
+				return fmt.Errorf("%w: %v", cons.EVENT_INVALID, err)
+			}
+
+			if errors != nil {
+				return fmt.Errorf("%w: %v", cons.EVENT_INVALID, errors.Errors)
+			}
+
+			return nil
+		},
+	}
+}
@@ -16,17 +16,21 @@ func Graceful(req dto.Request[dto.Environtment], Handler func() opt.Graceful) er
 	h := Handler()
 	secure := true
 
+	if h.PORT == "" {
+		h.PORT = h.ENV.APP.PORT
+	}
+
 	if _, ok := os.LookupEnv("GO_ENV"); ok && req.Config.APP.ENV != cons.DEV {
 		secure = false
 	}
 
 	server := http.Server{
 		Handler:        h.HANDLER,
-		Addr:           ":" + h.ENV.APP.PORT,
+		Addr:           ":" + h.PORT,
 		MaxHeaderBytes: req.Config.APP.INBOUND_SIZE,
 		TLSConfig:      &tls.Config{InsecureSkipVerify: secure},
 	}
 
-	Logrus(cons.INFO, "Server listening on port %s", h.ENV.APP.PORT)
+	Logrus(cons.INFO, "Server listening on port %s", h.PORT)
 	return graceful.Graceful(server.ListenAndServe, server.Shutdown)
 }
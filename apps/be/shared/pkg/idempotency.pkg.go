@@ -0,0 +1,105 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+/**
+* KEYS[1] = version key
+* ARGV[1] = version, ARGV[2] = ttl in milliseconds
+ */
+var idempotencyAdvanceScript = goredis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+
+if current ~= false and tonumber(current) > tonumber(ARGV[1]) then
+	return 0
+end
+
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return 1
+`)
+
+type idempotency struct {
+	ctx        context.Context
+	redis      *goredis.Client
+	name       string
+	ttl        time.Duration
+	versionTTL time.Duration
+}
+
+func NewIdempotency(ctx context.Context, con *goredis.Client, options dto.IdempotencyOptions) inf.IIdempotency {
+	if options.TTL <= 0 {
+		options.TTL = time.Duration(time.Hour * 24)
+	}
+
+	if options.VersionTTL <= 0 {
+		options.VersionTTL = time.Duration(time.Hour * 24 * 7)
+	}
+
+	return idempotency{ctx: ctx, redis: con, name: options.Name, ttl: options.TTL, versionTTL: options.VersionTTL}
+}
+
+/**
+* Claim the event id before it is applied, false means the event is already processed or still in progress
+ */
+func (p idempotency) Claim(eventID string) (bool, error) {
+	claimed, err := p.redis.SetNX(p.ctx, fmt.Sprintf(cons.IDEMPOTENCY_EVENT_KEY, p.name, eventID), time.Now().Unix(), p.ttl).Result()
+	if err != nil {
+		return false, err
+	}
+
+	if !claimed {
+		p.incr(cons.IDEMPOTENCY_DUPLICATE)
+	}
+
+	return claimed, nil
+}
+
+/**
+* Release the claimed event id when the event failed, so the retry or replay is not treated as duplicate
+ */
+func (p idempotency) Release(eventID string) error {
+	return p.redis.Del(p.ctx, fmt.Sprintf(cons.IDEMPOTENCY_EVENT_KEY, p.name, eventID)).Err()
+}
+
+/**
+* Move the document high water mark forward, false means a newer event is already applied to the document
+ */
+func (p idempotency) Advance(doc, id string, version int64) (bool, error) {
+	key := fmt.Sprintf(cons.IDEMPOTENCY_VERSION_KEY, p.name, doc, id)
+
+	advanced, err := idempotencyAdvanceScript.Run(p.ctx, p.redis, []string{key}, version, p.versionTTL.Milliseconds()).Int64()
+	if err != nil {
+		return false, err
+	}
+
+	if advanced < 1 {
+		p.incr(cons.IDEMPOTENCY_STALE)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (p idempotency) Count(kind string) (int64, error) {
+	count, err := p.redis.HGet(p.ctx, fmt.Sprintf(cons.IDEMPOTENCY_METRICS_KEY, p.name), kind).Int64()
+	if err != nil && err != goredis.Nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (p idempotency) incr(kind string) {
+	if err := p.redis.HIncrBy(p.ctx, fmt.Sprintf(cons.IDEMPOTENCY_METRICS_KEY, p.name), kind, 1).Err(); err != nil {
+		Logrus(cons.ERROR, err)
+	}
+}
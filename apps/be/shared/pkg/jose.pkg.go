@@ -13,6 +13,7 @@ import (
 	"github.com/lestrrat-go/jwx/v3/jws"
 	"github.com/lestrrat-go/jwx/v3/jwt"
 
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
 	"github.com/restuwahyu13/go-fast-search/shared/dto"
 	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
 	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
@@ -159,6 +160,10 @@ func (p jose) JwtSign(options *dto.JwtSignOption) ([]byte, error) {
 	jwtBuilder.JwtID(options.Jti)
 	jwtBuilder.Claim("timestamp", options.Claim)
 
+	for name, value := range options.Claims {
+		jwtBuilder.Claim(name, value)
+	}
+
 	jwtToken, err := jwtBuilder.Build()
 	if err != nil {
 		return nil, err
@@ -172,7 +177,11 @@ func (p jose) JwtSign(options *dto.JwtSignOption) ([]byte, error) {
 	return token, nil
 }
 
-func (p jose) JwtVerify(prefix string, token string, redis inf.IRedis) (*jwt.Token, error) {
+/**
+* JwtVerify look up the verification key by kid in keyset, token signed before keyset exist carry the jwe ciphertext
+* as kid and is verified with the key generated for its prefix until it expired
+ */
+func (p jose) JwtVerify(prefix string, token string, redis inf.IRedis, keyset inf.IKeyset) (*jwt.Token, error) {
 	signatureKey := fmt.Sprintf("CREDENTIAL:%s", prefix)
 	signatureMetadataField := "signature_metadata"
 
@@ -190,11 +199,6 @@ func (p jose) JwtVerify(prefix string, token string, redis inf.IRedis) (*jwt.Tok
 		return nil, errors.New("Invalid secretkey or signature")
 	}
 
-	privateKey, err := p.cert.PrivateKeyRawToKey([]byte(signatureMetadata.PrivKeyRaw), []byte(signatureMetadata.CipherKey))
-	if err != nil {
-		return nil, err
-	}
-
 	exportJws, err := jws.ParseString(token)
 	if err != nil {
 		return nil, err
@@ -221,18 +225,38 @@ func (p jose) JwtVerify(prefix string, token string, redis inf.IRedis) (*jwt.Tok
 	}
 
 	kid, ok := jwsHeaders.KeyID()
-	if !ok {
-		return nil, errors.New("Invalid keyid")
-	} else if kid != signatureMetadata.JweKey.CipherText {
+	if !ok || kid == "" {
 		return nil, errors.New("Invalid keyid")
 	}
 
+	publicKey := new(rsa.PublicKey)
+
+	if kid == signatureMetadata.JweKey.CipherText {
+		privateKey, err := p.cert.PrivateKeyRawToKey([]byte(signatureMetadata.PrivKeyRaw), []byte(signatureMetadata.CipherKey))
+		if err != nil {
+			return nil, err
+		}
+
+		publicKey = &privateKey.PublicKey
+	} else {
+		signingKey, err := keyset.Key(kid)
+		if errors.Is(err, cons.KEYSET_KEY_NOT_FOUND) {
+			return nil, errors.New("Invalid keyid")
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		publicKey = &signingKey.PrivateKey.PublicKey
+	}
+
 	aud := signatureMetadata.SigKey[10:20]
 	iss := signatureMetadata.SigKey[30:40]
 	sub := signatureMetadata.SigKey[50:60]
 	claim := "timestamp"
 
-	jwkKey, err := jwk.Import(privateKey)
+	jwkKey, err := jwk.Import(publicKey)
 	if err != nil {
 		return nil, err
 	}
@@ -243,7 +267,7 @@ func (p jose) JwtVerify(prefix string, token string, redis inf.IRedis) (*jwt.Tok
 	}
 
 	jwtParse, err := jwt.Parse([]byte(token),
-		jwt.WithKey(algorithm, privateKey),
+		jwt.WithKey(algorithm, publicKey),
 		jwt.WithAudience(aud),
 		jwt.WithIssuer(iss),
 		jwt.WithSubject(sub),
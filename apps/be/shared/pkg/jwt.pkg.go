@@ -5,12 +5,15 @@ import (
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
 	"time"
 
+	"github.com/lestrrat-go/jwx/v3/jwt"
 	goredis "github.com/redis/go-redis/v9"
 
 	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
@@ -20,9 +23,21 @@ import (
 	opt "github.com/restuwahyu13/go-fast-search/shared/output"
 )
 
+var jwtRegisteredClaims = map[string]struct{}{
+	"aud":       {},
+	"iss":       {},
+	"sub":       {},
+	"exp":       {},
+	"nbf":       {},
+	"iat":       {},
+	"jti":       {},
+	"timestamp": {},
+}
+
 type jsonWebToken struct {
 	env       *dto.Environtment
 	rds       inf.IRedis
+	keyset    inf.IKeyset
 	jose      inf.IJose
 	cipher    inf.ICrypto
 	cert      inf.ICert
@@ -46,6 +61,7 @@ func NewJsonWebToken(ctx context.Context, env *dto.Environtment, con *goredis.Cl
 	return jsonWebToken{
 		env:       env,
 		rds:       rds,
+		keyset:    NewKeyset(ctx, env, con),
 		jose:      jose,
 		cipher:    cipher,
 		cert:      cert,
@@ -177,6 +193,16 @@ func (p jsonWebToken) Sign(prefix string, body any) (*opt.SignMetadata, error) {
 			return nil, err
 		}
 
+		claims, err := p.claims(body)
+		if err != nil {
+			return nil, err
+		}
+
+		signingKey, err := p.keyset.Active()
+		if err != nil {
+			return nil, err
+		}
+
 		timestamp := time.Now().Format(cons.DATE_TIME_FORMAT)
 		aud := signature.SigKey[10:20]
 		iss := signature.SigKey[30:40]
@@ -197,8 +223,8 @@ func (p jsonWebToken) Sign(prefix string, body any) (*opt.SignMetadata, error) {
 
 		tokenPayload := new(dto.JwtSignOption)
 		tokenPayload.SecretKey = signature.CipherKey
-		tokenPayload.Kid = signature.JweKey.CipherText
-		tokenPayload.PrivateKey = signature.PrivKey
+		tokenPayload.Kid = signingKey.Kid
+		tokenPayload.PrivateKey = signingKey.PrivateKey
 		tokenPayload.Aud = []string{aud}
 		tokenPayload.Iss = iss
 		tokenPayload.Sub = sub
@@ -206,6 +232,7 @@ func (p jsonWebToken) Sign(prefix string, body any) (*opt.SignMetadata, error) {
 		tokenPayload.Iat = jwtIat
 		tokenPayload.Exp = jwtExp
 		tokenPayload.Claim = timestamp
+		tokenPayload.Claims = claims
 
 		tokenData, err := p.jose.JwtSign(tokenPayload)
 		if err != nil {
@@ -232,3 +259,161 @@ func (p jsonWebToken) Sign(prefix string, body any) (*opt.SignMetadata, error) {
 		return signMetadataRes, nil
 	}
 }
+
+/**
+* Verify resolve the prefix from encrypted jti and verify token signature with signature metadata stored in redis,
+* revoked token still return the resolved metadata together with AUTH_TOKEN_REVOKED for reuse detection
+ */
+func (p jsonWebToken) Verify(token string) (*opt.VerifyMetadata, error) {
+	verifyMetadataRes := new(opt.VerifyMetadata)
+
+	tokenMetadata, err := jwt.ParseString(token, jwt.WithVerify(false))
+	if err != nil {
+		return nil, err
+	}
+
+	aud, ok := tokenMetadata.Audience()
+	if !ok || len(aud) < 1 {
+		return nil, cons.AUTH_TOKEN_INVALID
+	}
+
+	iss, ok := tokenMetadata.Issuer()
+	if !ok {
+		return nil, cons.AUTH_TOKEN_INVALID
+	}
+
+	sub, ok := tokenMetadata.Subject()
+	if !ok {
+		return nil, cons.AUTH_TOKEN_INVALID
+	}
+
+	jti, ok := tokenMetadata.JwtID()
+	if !ok {
+		return nil, cons.AUTH_TOKEN_INVALID
+	}
+
+	exp, ok := tokenMetadata.Expiration()
+	if !ok {
+		return nil, cons.AUTH_TOKEN_INVALID
+	}
+
+	timestamp := ""
+	if err := tokenMetadata.Get("timestamp", &timestamp); err != nil {
+		return nil, err
+	}
+
+	suffix := int(math.Pow(float64(p.env.JWT.EXPIRED), float64(len(aud[0])+len(iss)+len(sub))))
+	secretKey := fmt.Sprintf("%s:%s:%s:%s:%d", aud[0], iss, sub, timestamp, suffix)
+	secretData := hex.EncodeToString([]byte(secretKey))
+
+	prefix, err := p.cipher.AES256Decrypt(secretData, jti)
+	if err != nil {
+		return nil, err
+	}
+
+	verifyMetadataRes.Prefix = prefix
+	verifyMetadataRes.Jti = jti
+	verifyMetadataRes.ExpiredAt = exp
+
+	revoked, err := p.rds.Exists(p.revokedKey(jti))
+	if err != nil {
+		return nil, err
+	}
+
+	if revoked > 0 {
+		return verifyMetadataRes, cons.AUTH_TOKEN_REVOKED
+	}
+
+	tokenVerified, err := p.jose.JwtVerify(prefix, token, p.rds, p.keyset)
+	if err != nil {
+		return nil, err
+	}
+
+	verifyMetadataRes.Claims = make(map[string]any)
+
+	for _, name := range (*tokenVerified).Keys() {
+		if _, ok := jwtRegisteredClaims[name]; ok {
+			continue
+		}
+
+		var value any
+		if err := (*tokenVerified).Get(name, &value); err != nil {
+			return nil, err
+		}
+
+		verifyMetadataRes.Claims[name] = value
+	}
+
+	return verifyMetadataRes, nil
+}
+
+/**
+* Revoke delete the cached token and signature metadata of prefix, so jose verify fail for every token signed with it,
+* the jti is kept in revocation list until the token expired
+ */
+func (p jsonWebToken) Revoke(prefix string) error {
+	tokenKey := fmt.Sprintf("TOKEN:%s", prefix)
+	signatureKey := fmt.Sprintf("CREDENTIAL:%s", prefix)
+
+	tokenData, err := p.rds.Get(tokenKey)
+	if err != nil && !errors.Is(err, goredis.Nil) {
+		return err
+	}
+
+	if len(tokenData) > 0 {
+		tokenMetadata, err := jwt.ParseString(string(tokenData), jwt.WithVerify(false), jwt.WithValidate(false))
+		if err != nil {
+			return err
+		}
+
+		jti, _ := tokenMetadata.JwtID()
+		exp, _ := tokenMetadata.Expiration()
+
+		if ttl := time.Until(exp); jti != "" && ttl > 0 {
+			if err := p.rds.SetEx(p.revokedKey(jti), ttl, time.Now().Format(time.RFC3339)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := p.rds.Del(tokenKey); err != nil {
+		return err
+	}
+
+	if _, err := p.rds.Del(signatureKey); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// claims convert sign body into private claims, registered claim name in body is ignored
+func (p jsonWebToken) claims(body any) (map[string]any, error) {
+	claims := make(map[string]any)
+
+	bodyByte, err := p.parser.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bodyByte) < 1 || bodyByte[0] != '{' {
+		return claims, nil
+	}
+
+	if err := p.parser.Unmarshal(bodyByte, &claims); err != nil {
+		return nil, err
+	}
+
+	for name := range claims {
+		if _, ok := jwtRegisteredClaims[name]; ok {
+			delete(claims, name)
+		}
+	}
+
+	return claims, nil
+}
+
+func (p jsonWebToken) revokedKey(jti string) string {
+	hash := sha256.Sum256([]byte(jti))
+	return fmt.Sprintf(cons.AUTH_REVOKED_KEY, hex.EncodeToString(hash[:]))
+}
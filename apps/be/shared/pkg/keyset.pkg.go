@@ -0,0 +1,326 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v3/jwa"
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	goredis "github.com/redis/go-redis/v9"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+/**
+* KEYS[1] = active kid key, KEYS[2] = retired kid sorted set
+* ARGV[1] = current active kid, ARGV[2] = next active kid, ARGV[3] = unix time the current kid stop being valid
+ */
+var keysetRotateScript = goredis.NewScript(`
+if (redis.call('GET', KEYS[1]) or '') == ARGV[1] then
+	redis.call('SET', KEYS[1], ARGV[2])
+
+	if ARGV[1] ~= '' then
+		redis.call('ZADD', KEYS[2], ARGV[3], ARGV[1])
+	end
+
+	return 1
+end
+
+return 0
+`)
+
+// keysetCache hold decrypted signing key by kid, key material never change for the same kid
+var keysetCache sync.Map
+
+type keyset struct {
+	ctx      context.Context
+	env      *dto.Environtment
+	redis    *goredis.Client
+	jose     inf.IJose
+	cert     inf.ICert
+	rotation time.Duration
+	grace    time.Duration
+}
+
+/**
+* Keyset manage the rs512 signing keys shared by every api instance, the active key sign new token and
+* the retired key is still valid for verification until the grace period is over, grace default to the
+* longest token lifetime so token signed right before rotation is never rejected
+ */
+func NewKeyset(ctx context.Context, env *dto.Environtment, con *goredis.Client) inf.IKeyset {
+	rotation := env.JWT.KEY_ROTATION
+	if rotation <= 0 {
+		rotation = cons.KEYSET_ROTATION
+	}
+
+	grace := env.JWT.KEY_GRACE
+	if grace <= 0 {
+		grace = max(env.JWT.EXPIRED, env.JWT.REFRESH_EXPIRED, cons.AUTH_REFRESH_EXPIRED)
+	}
+
+	return keyset{
+		ctx:      ctx,
+		env:      env,
+		redis:    con,
+		jose:     NewJose(ctx),
+		cert:     helper.NewCert(),
+		rotation: time.Duration(time.Minute * time.Duration(rotation)),
+		grace:    time.Duration(time.Minute * time.Duration(grace)),
+	}
+}
+
+// Active return the key signing new token, the first key is generated when the keyset is empty
+func (p keyset) Active() (*opt.SigningKeyMetadata, error) {
+	kid, err := p.redis.Get(p.ctx, cons.KEYSET_ACTIVE_KEY).Result()
+	if errors.Is(err, goredis.Nil) {
+		return p.rotate("")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return p.load(kid, time.Time{})
+}
+
+// Key return the active key or retired key still in grace period, otherwise KEYSET_KEY_NOT_FOUND
+func (p keyset) Key(kid string) (*opt.SigningKeyMetadata, error) {
+	if kid == "" {
+		return nil, cons.KEYSET_KEY_NOT_FOUND
+	}
+
+	active, err := p.redis.Get(p.ctx, cons.KEYSET_ACTIVE_KEY).Result()
+	if err != nil && !errors.Is(err, goredis.Nil) {
+		return nil, err
+	}
+
+	if kid == active {
+		return p.load(kid, time.Time{})
+	}
+
+	score, err := p.redis.ZScore(p.ctx, cons.KEYSET_RETIRED_KEY, kid).Result()
+	if errors.Is(err, goredis.Nil) {
+		return nil, cons.KEYSET_KEY_NOT_FOUND
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	retiredAt := time.Unix(int64(score), 0)
+	if !time.Now().Before(retiredAt) {
+		return nil, cons.KEYSET_KEY_NOT_FOUND
+	}
+
+	return p.load(kid, retiredAt)
+}
+
+/**
+* Rotate replace the active key when it is older than the rotation interval, the previous key is retired
+* with grace period and key retired past the grace period is removed, nil is returned when rotation is not due
+ */
+func (p keyset) Rotate() (*opt.SigningKeyMetadata, error) {
+	if err := p.prune(); err != nil {
+		return nil, err
+	}
+
+	active, err := p.Active()
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Since(active.CreatedAt) < p.rotation {
+		return nil, nil
+	}
+
+	return p.rotate(active.Kid)
+}
+
+// Jwks export public part of the active and retired keys, verifier refetch the set when it meet unknown kid
+func (p keyset) Jwks() (jwk.Set, error) {
+	jwkSet := jwk.NewSet()
+
+	active, err := p.Active()
+	if err != nil {
+		return nil, err
+	}
+
+	kids, err := p.redis.ZRangeByScore(p.ctx, cons.KEYSET_RETIRED_KEY, &goredis.ZRangeBy{Min: fmt.Sprintf("(%d", time.Now().Unix()), Max: "+inf"}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, kid := range append([]string{active.Kid}, kids...) {
+		signingKey, err := p.Key(kid)
+		if errors.Is(err, cons.KEYSET_KEY_NOT_FOUND) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		jwkMetadata, err := p.jose.ExportJsonWebKey(signingKey.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+
+		publicKey, err := jwk.PublicKeyOf(jwkMetadata.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := publicKey.Set(jwk.KeyIDKey, signingKey.Kid); err != nil {
+			return nil, err
+		}
+
+		if err := publicKey.Set(jwk.KeyUsageKey, cons.KEYSET_KEY_USAGE); err != nil {
+			return nil, err
+		}
+
+		if err := publicKey.Set(jwk.AlgorithmKey, jwa.RS512()); err != nil {
+			return nil, err
+		}
+
+		if err := jwkSet.AddKey(publicKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return jwkSet, nil
+}
+
+/**
+* rotate generate new key with kid from its jwk thumbprint and swap the active kid only when it is still current,
+* losing the race against another instance drop the generated key and return the winner
+ */
+func (p keyset) rotate(current string) (*opt.SigningKeyMetadata, error) {
+	password := []byte(p.env.JWT.SECRET)
+
+	privKeyRaw, err := p.cert.GeneratePrivateKey(password)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := p.cert.PrivateKeyRawToKey([]byte(privKeyRaw), password)
+	if err != nil {
+		return nil, err
+	}
+
+	jwkKey, err := jwk.Import(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := jwk.AssignKeyID(jwkKey); err != nil {
+		return nil, err
+	}
+
+	kid, ok := jwkKey.KeyID()
+	if !ok {
+		return nil, errors.New("Invalid keyid")
+	}
+
+	signingKeyMetadataReq := dto.SigningKeyMetadata{Kid: kid, PrivKeyRaw: privKeyRaw, CreatedAt: time.Now()}
+
+	signingKeyMetadataByte, err := json.Marshal(signingKeyMetadataReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.redis.HSet(p.ctx, cons.KEYSET_KEYS_KEY, kid, string(signingKeyMetadataByte)).Err(); err != nil {
+		return nil, err
+	}
+
+	retiredAt := time.Now().Add(p.grace).Unix()
+
+	rotated, err := keysetRotateScript.Run(p.ctx, p.redis, []string{cons.KEYSET_ACTIVE_KEY, cons.KEYSET_RETIRED_KEY}, current, kid, retiredAt).Int()
+	if err != nil {
+		return nil, err
+	}
+
+	if rotated < 1 {
+		if err := p.redis.HDel(p.ctx, cons.KEYSET_KEYS_KEY, kid).Err(); err != nil {
+			return nil, err
+		}
+
+		winner, err := p.redis.Get(p.ctx, cons.KEYSET_ACTIVE_KEY).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		return p.load(winner, time.Time{})
+	}
+
+	signingKeyMetadataRes := opt.SigningKeyMetadata{Kid: kid, PrivateKey: privateKey, CreatedAt: signingKeyMetadataReq.CreatedAt}
+	keysetCache.Store(kid, signingKeyMetadataRes)
+
+	return &signingKeyMetadataRes, nil
+}
+
+func (p keyset) load(kid string, retiredAt time.Time) (*opt.SigningKeyMetadata, error) {
+	if cached, ok := keysetCache.Load(kid); ok {
+		signingKeyMetadataRes := cached.(opt.SigningKeyMetadata)
+		signingKeyMetadataRes.RetiredAt = retiredAt
+
+		return &signingKeyMetadataRes, nil
+	}
+
+	signingKeyMetadataByte, err := p.redis.HGet(p.ctx, cons.KEYSET_KEYS_KEY, kid).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, cons.KEYSET_KEY_NOT_FOUND
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	signingKeyMetadataReq := dto.SigningKeyMetadata{}
+	if err := json.Unmarshal(signingKeyMetadataByte, &signingKeyMetadataReq); err != nil {
+		return nil, err
+	}
+
+	privateKey, err := p.cert.PrivateKeyRawToKey([]byte(signingKeyMetadataReq.PrivKeyRaw), []byte(p.env.JWT.SECRET))
+	if err != nil {
+		return nil, err
+	}
+
+	signingKeyMetadataRes := opt.SigningKeyMetadata{Kid: kid, PrivateKey: privateKey, CreatedAt: signingKeyMetadataReq.CreatedAt}
+	keysetCache.Store(kid, signingKeyMetadataRes)
+
+	signingKeyMetadataRes.RetiredAt = retiredAt
+
+	return &signingKeyMetadataRes, nil
+}
+
+func (p keyset) prune() error {
+	kids, err := p.redis.ZRangeByScore(p.ctx, cons.KEYSET_RETIRED_KEY, &goredis.ZRangeBy{Min: "-inf", Max: fmt.Sprintf("%d", time.Now().Unix())}).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, kid := range kids {
+		_, err := p.redis.TxPipelined(p.ctx, func(pipe goredis.Pipeliner) error {
+			pipe.HDel(p.ctx, cons.KEYSET_KEYS_KEY, kid)
+			pipe.ZRem(p.ctx, cons.KEYSET_RETIRED_KEY, kid)
+
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+
+		keysetCache.Delete(kid)
+	}
+
+	return nil
+}
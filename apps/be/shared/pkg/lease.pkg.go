@@ -0,0 +1,322 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lithammer/shortuuid"
+	goredis "github.com/redis/go-redis/v9"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+/**
+* KEYS[1] = lease key, KEYS[2] = token counter key, KEYS[3] = registry key
+* ARGV[1] = holder, ARGV[2] = ttl in milliseconds, ARGV[3] = expired at, ARGV[4] = lease name
+ */
+var leaseAcquireScript = goredis.NewScript(`
+local holder = redis.call('HGET', KEYS[1], 'holder')
+
+if holder == false then
+	local token = redis.call('INCR', KEYS[2])
+	redis.call('HSET', KEYS[1], 'holder', ARGV[1], 'token', token, 'expired_at', ARGV[3])
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	redis.call('SADD', KEYS[3], ARGV[4])
+	return token
+elseif holder == ARGV[1] then
+	redis.call('HSET', KEYS[1], 'expired_at', ARGV[3])
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	return tonumber(redis.call('HGET', KEYS[1], 'token'))
+end
+
+return 0
+`)
+
+/**
+* KEYS[1] = lease key
+* ARGV[1] = holder, ARGV[2] = token, ARGV[3] = ttl in milliseconds, ARGV[4] = expired at
+ */
+var leaseRenewScript = goredis.NewScript(`
+if redis.call('HGET', KEYS[1], 'holder') == ARGV[1] and redis.call('HGET', KEYS[1], 'token') == ARGV[2] then
+	redis.call('HSET', KEYS[1], 'expired_at', ARGV[4])
+	redis.call('PEXPIRE', KEYS[1], ARGV[3])
+	return 1
+end
+
+return 0
+`)
+
+/**
+* KEYS[1] = lease key
+* ARGV[1] = holder, ARGV[2] = token
+ */
+var leaseReleaseScript = goredis.NewScript(`
+if redis.call('HGET', KEYS[1], 'holder') == ARGV[1] and redis.call('HGET', KEYS[1], 'token') == ARGV[2] then
+	return redis.call('DEL', KEYS[1])
+end
+
+return 0
+`)
+
+/**
+* KEYS[1] = lease key, KEYS[2] = fence key
+* ARGV[1] = holder, ARGV[2] = token
+ */
+var leaseFenceScript = goredis.NewScript(`
+if redis.call('HGET', KEYS[1], 'holder') == ARGV[1] and redis.call('HGET', KEYS[1], 'token') == ARGV[2] then
+	local fence = tonumber(redis.call('GET', KEYS[2]) or '0')
+
+	if tonumber(ARGV[2]) >= fence then
+		redis.call('SET', KEYS[2], ARGV[2])
+		return tonumber(ARGV[2])
+	end
+end
+
+return 0
+`)
+
+type lease struct {
+	ctx       context.Context
+	redis     *goredis.Client
+	name      string
+	holder    string
+	ttl       time.Duration
+	interval  time.Duration
+	mutex     sync.RWMutex
+	token     int64
+	expiredAt time.Time
+}
+
+func NewLease(ctx context.Context, con *goredis.Client, options dto.LeaseOptions) inf.ILease {
+	if options.TTL <= 0 {
+		options.TTL = time.Duration(time.Second * 15)
+	}
+
+	if options.Interval <= 0 {
+		options.Interval = options.TTL / 3
+	}
+
+	if options.Holder == "" {
+		hostname, _ := os.Hostname()
+		options.Holder = fmt.Sprintf("%s:%d:%s", hostname, os.Getpid(), shortuuid.New())
+	}
+
+	return &lease{
+		ctx:      ctx,
+		redis:    con,
+		name:     options.Name,
+		holder:   options.Holder,
+		ttl:      options.TTL,
+		interval: options.Interval,
+	}
+}
+
+func (p *lease) key() string {
+	return fmt.Sprintf(cons.LEASE_KEY, p.name)
+}
+
+func (p *lease) tokenKey() string {
+	return fmt.Sprintf(cons.LEASE_TOKEN_KEY, p.name)
+}
+
+func (p *lease) fenceKey() string {
+	return fmt.Sprintf(cons.LEASE_FENCE_KEY, p.name)
+}
+
+func (p *lease) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.keepalive()
+
+		select {
+		case <-ctx.Done():
+			if err := p.Release(); err != nil {
+				Logrus(cons.ERROR, err)
+			}
+
+			return
+
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *lease) keepalive() {
+	if p.IsLeader() {
+		renewed, err := p.Renew()
+		if err != nil {
+			Logrus(cons.ERROR, err)
+			return
+		}
+
+		if !renewed {
+			Logrus(cons.INFO, "Lease %s is lost by holder %s", p.name, p.holder)
+		}
+
+		return
+	}
+
+	acquired, err := p.Acquire()
+	if err != nil {
+		Logrus(cons.ERROR, err)
+		return
+	}
+
+	if acquired {
+		Logrus(cons.INFO, "Lease %s is acquired by holder %s with token %d", p.name, p.holder, p.Token())
+	}
+}
+
+func (p *lease) Acquire() (bool, error) {
+	now := time.Now()
+	expiredAt := now.Add(p.ttl)
+
+	keys := []string{p.key(), p.tokenKey(), cons.LEASE_REGISTRY_KEY}
+	token, err := leaseAcquireScript.Run(p.ctx, p.redis, keys, p.holder, p.ttl.Milliseconds(), expiredAt.Format(time.RFC3339), p.name).Int64()
+	if err != nil {
+		return false, err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if token < 1 {
+		p.token = 0
+		return false, nil
+	}
+
+	p.token = token
+	p.expiredAt = expiredAt
+
+	return true, nil
+}
+
+func (p *lease) Renew() (bool, error) {
+	token := p.Token()
+	if token < 1 {
+		return false, nil
+	}
+
+	now := time.Now()
+	expiredAt := now.Add(p.ttl)
+
+	renewed, err := leaseRenewScript.Run(p.ctx, p.redis, []string{p.key()}, p.holder, token, p.ttl.Milliseconds(), expiredAt.Format(time.RFC3339)).Int64()
+	if err != nil {
+		return false, err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if renewed < 1 {
+		p.token = 0
+		return false, nil
+	}
+
+	p.expiredAt = expiredAt
+
+	return true, nil
+}
+
+/**
+* Release run with its own timeout instead of the lease context, release mostly happen on shutdown
+* after that context is canceled and the standby would otherwise wait the whole ttl
+ */
+func (p *lease) Release() error {
+	token := p.Token()
+	if token < 1 {
+		return nil
+	}
+
+	p.mutex.Lock()
+	p.token = 0
+	p.mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(time.Second*cons.LEASE_RELEASE_TIMEOUT))
+	defer cancel()
+
+	if _, err := leaseReleaseScript.Run(ctx, p.redis, []string{p.key()}, p.holder, token).Int64(); err != nil {
+		return err
+	}
+
+	Logrus(cons.INFO, "Lease %s is released by holder %s", p.name, p.holder)
+	return nil
+}
+
+func (p *lease) IsLeader() bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return p.token > 0 && time.Now().Before(p.expiredAt)
+}
+
+func (p *lease) Token() int64 {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return p.token
+}
+
+/**
+* Fence check the lease is still held and raise the fence high water mark to the token in one script, a holder
+* with an older token than the mark is rejected, the returned token is written with the data so stores that
+* support a conditional write can reject a stale holder themselves
+ */
+func (p *lease) Fence() (int64, error) {
+	token := p.Token()
+	if !p.IsLeader() {
+		return 0, cons.LEASE_NOT_HELD
+	}
+
+	fenced, err := leaseFenceScript.Run(p.ctx, p.redis, []string{p.key(), p.fenceKey()}, p.holder, token).Int64()
+	if err != nil {
+		return 0, err
+	}
+
+	if fenced < 1 {
+		return 0, cons.LEASE_NOT_HELD
+	}
+
+	return fenced, nil
+}
+
+func (p *lease) Status() (*opt.LeaseMetadata, error) {
+	res := new(opt.LeaseMetadata)
+	res.Name = p.name
+
+	result, err := p.redis.HGetAll(p.ctx, p.key()).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) < 1 {
+		return res, nil
+	}
+
+	ttl, err := p.redis.PTTL(p.ctx, p.key()).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := strconv.ParseInt(result["token"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Holder = result["holder"]
+	res.Token = token
+	res.ExpiredAt = result["expired_at"]
+	res.TTL = ttl.Milliseconds()
+	res.IsHeld = ttl > 0
+
+	return res, nil
+}
@@ -0,0 +1,75 @@
+package pkg
+
+import (
+	"context"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+/**
+* KEYS[1] = bucket key
+* ARGV[1] = bucket capacity, ARGV[2] = window in milliseconds to refill the whole capacity
+* return allowed, remaining tokens, milliseconds until next token and milliseconds until bucket is full
+ */
+var limiterTakeScript = goredis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local rate = limit / window
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'at')
+local tokens = tonumber(bucket[1]) or limit
+local at = tonumber(bucket[2]) or now
+
+tokens = math.min(limit, tokens + math.max(0, now - at) * rate)
+
+local allowed = 0
+local retry = 0
+
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'at', now)
+redis.call('PEXPIRE', KEYS[1], window)
+
+return {allowed, math.floor(tokens), retry, math.ceil((limit - tokens) / rate)}
+`)
+
+type limiter struct {
+	ctx   context.Context
+	redis *goredis.Client
+}
+
+/**
+* Limiter is token bucket shared by every api instance, bucket hold limit tokens and refill limit tokens per window,
+* so burst up to limit is accepted and sustained rate is limit per window, clock is taken from redis
+ */
+func NewLimiter(ctx context.Context, con *goredis.Client) inf.ILimiter {
+	return limiter{ctx: ctx, redis: con}
+}
+
+func (p limiter) Allow(key string, limit int, window time.Duration) (*opt.RateLimitMetadata, error) {
+	result, err := limiterTakeScript.Run(p.ctx, p.redis, []string{key}, limit, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimitMetadataRes := new(opt.RateLimitMetadata)
+	rateLimitMetadataRes.Allowed = result[0] == 1
+	rateLimitMetadataRes.Limit = limit
+	rateLimitMetadataRes.Remaining = int(result[1])
+	rateLimitMetadataRes.RetryAfter = int((time.Duration(result[2])*time.Millisecond + time.Second - 1) / time.Second)
+	rateLimitMetadataRes.Reset = int((time.Duration(result[3])*time.Millisecond + time.Second - 1) / time.Second)
+
+	return rateLimitMetadataRes, nil
+}
@@ -1,80 +1,59 @@
 package pkg
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/sirupsen/logrus"
+
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
 )
 
+/**
+* Every message is formatted first and redacted before it reach the logger,
+* so personal data never end up in the log whatever the call site pass
+ */
 func Logrus(Type string, Msg any, Args ...any) {
-	format := false
 	logrus.SetFormatter(&logrus.TextFormatter{
 		TimestampFormat: time.RFC3339,
 	})
 
+	message := fmt.Sprint(Msg)
+
 	if Args != nil {
-		format = true
+		message = fmt.Sprintf(message, Args...)
 	}
 
+	message = helper.Redact(message)
+
 	switch Type {
 
 	case "info":
-		if format {
-			logrus.Infof(Msg.(string), Args...)
-		} else {
-			logrus.Info(Msg)
-		}
-
+		logrus.Info(message)
 		break
 
 	case "error":
-		if format {
-			logrus.Errorf(Msg.(string), Args...)
-		} else {
-			logrus.Error(Msg)
-		}
-
+		logrus.Error(message)
 		break
 
 	case "print":
-		if format {
-			logrus.Printf(Msg.(string), Args...)
-		} else {
-			logrus.Print(Msg)
-		}
-
+		logrus.Print(message)
 		break
 
 	case "fatal":
-		if format {
-			logrus.Fatalf(Msg.(string), Args...)
-		} else {
-			logrus.Fatal(Msg)
-		}
-
+		logrus.Fatal(message)
 		break
 
 	case "debug":
-		if format {
-			logrus.Debugf(Msg.(string), Args...)
-		} else {
-			logrus.Debug(Msg)
-		}
-
+		logrus.Debug(message)
 		break
 
 	case "panic":
-		if format {
-			logrus.Panicf(Msg.(string), Args...)
-		} else {
-			logrus.Panic(Msg)
-		}
-
+		logrus.Panic(message)
 		break
 
 	default:
-		logrus.Println(Msg)
-
+		logrus.Println(message)
 		break
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"reflect"
 	"slices"
 	"time"
@@ -182,7 +183,48 @@ func (p meilisearch) BulkUpdate(doc string, value any) (*search.TaskInfo, error)
 	return task, nil
 }
 
-func (p meilisearch) Delete(doc string, id string) (*search.TaskInfo, error) {
+/**
+* Upsert partially update the documents without checking they exist and without waiting the task,
+* the document is created when it is not exists yet
+ */
+func (p meilisearch) Upsert(doc string, value any) (*search.TaskInfo, error) {
+	if err := p.validate(doc, value); err != nil {
+		return nil, err
+	}
+
+	task, err := p.meilisearch.Index(doc).UpdateDocumentsWithContext(p.ctx, value)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.TaskUID < 1 {
+		return nil, cons.NO_ROWS_AFFECTED
+	}
+
+	return task, nil
+}
+
+/**
+* Wait until the task is processed, failed or canceled task is returned as error
+ */
+func (p meilisearch) Wait(task *search.TaskInfo) error {
+	ctx, cancel := context.WithTimeout(p.ctx, cons.MEILISEARCH_TASK_TIMEOUT)
+	defer cancel()
+
+	res, err := p.meilisearch.WaitForTaskWithContext(ctx, task.TaskUID, time.Duration(time.Millisecond*50))
+	if err != nil {
+		return err
+	}
+
+	if res.Status != search.TaskStatusSucceeded {
+		return fmt.Errorf("%w: task %d is %s %s", cons.MEILISEARCH_TASK_FAILED, task.TaskUID, res.Status, res.Error.Message)
+	}
+
+	return nil
+}
+
+// Delete mark the document as deleted with the deleted time of the source row, so replay always write the same value
+func (p meilisearch) Delete(doc string, id string, deletedAt int64) (*search.TaskInfo, error) {
 	res := make(map[string]any)
 
 	if err := p.validate(doc, nil); err != nil {
@@ -197,7 +239,7 @@ func (p meilisearch) Delete(doc string, id string) (*search.TaskInfo, error) {
 		return nil, sql.ErrNoRows
 	}
 
-	res["deleted_at"] = time.Now().Format(cons.DATE_TIME_FORMAT)
+	res["deleted_at"] = deletedAt
 
 	task, err := p.meilisearch.Index(doc).UpdateDocumentsWithContext(p.ctx, &res)
 	if err != nil {
@@ -215,28 +257,54 @@ func (p meilisearch) Delete(doc string, id string) (*search.TaskInfo, error) {
 	return task, nil
 }
 
-func (p meilisearch) BulkDelete(doc string, ids ...string) (*search.TaskInfo, error) {
-	resDoc := make(map[string]any)
-	resDcos := []map[string]any{}
+// BulkDelete mark every document as deleted with the deleted time of its source row keyed by document id
+func (p meilisearch) BulkDelete(doc string, deletedAt map[string]int64) (*search.TaskInfo, error) {
+	resDocs := new(search.DocumentsResult)
+
+	ids := make([]string, 0, len(deletedAt))
+	for id := range deletedAt {
+		ids = append(ids, id)
+	}
 
 	if err := p.validate(doc, nil); err != nil {
 		return nil, err
 	}
 
-	for _, id := range ids {
-		if err := p.FindOne(doc, id, &search.DocumentQuery{}, &resDoc); err != nil {
-			return nil, err
-		}
+	filter := &search.DocumentsQuery{Filter: helper.FilterIn("id", ids...), Fields: []string{"id"}, Limit: int64(len(ids))}
+	if err := p.Find(doc, filter, resDocs); err != nil {
+		return nil, err
+	}
 
-		if resDoc == nil {
-			return nil, sql.ErrNoRows
-		}
+	if len(resDocs.Results) < 1 {
+		return nil, sql.ErrNoRows
+	}
+
+	for _, resDoc := range resDocs.Results {
+		resDoc["deleted_at"] = deletedAt[fmt.Sprint(resDoc["id"])]
+	}
+
+	task, err := p.meilisearch.Index(doc).UpdateDocumentsWithContext(p.ctx, &resDocs.Results)
+	if err != nil {
+		return nil, err
+	}
 
-		resDoc["deleted_at"] = time.Now().Format(cons.DATE_TIME_FORMAT)
-		resDcos = append(resDcos, resDoc)
+	if task.TaskUID < 1 {
+		return nil, cons.NO_ROWS_AFFECTED
+	}
+
+	if _, err := p.meilisearch.WaitForTaskWithContext(p.ctx, task.TaskUID, time.Duration(time.Second*3)); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func (p meilisearch) BulkDestroy(doc string, ids ...string) (*search.TaskInfo, error) {
+	if err := p.validate(doc, nil); err != nil {
+		return nil, err
 	}
 
-	task, err := p.meilisearch.Index(doc).UpdateDocumentsWithContext(p.ctx, &resDcos)
+	task, err := p.meilisearch.Index(doc).DeleteDocumentsWithContext(p.ctx, ids)
 	if err != nil {
 		return nil, err
 	}
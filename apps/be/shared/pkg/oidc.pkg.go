@@ -0,0 +1,293 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/lestrrat-go/jwx/v3/jws"
+	"github.com/lestrrat-go/jwx/v3/jwt"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type (
+	oidc struct {
+		ctx     context.Context
+		env     *dto.Environtment
+		refresh time.Duration
+		leeway  time.Duration
+	}
+
+	oidcKeySet struct {
+		mutex     sync.Mutex
+		set       jwk.Set
+		err       error
+		fetchedAt time.Time
+		checkedAt time.Time
+	}
+)
+
+// oidcKeySets cache the jwks by source and shared across request, so the identity provider is not called per request
+var oidcKeySets sync.Map
+
+/**
+* Oidc verify jwt issued by external identity provider as resource server, the jwks is loaded from OIDC_JWKS_URL
+* which accept http url or local file path, so it can be pointed to a jwks file outside production
+ */
+func NewOidc(ctx context.Context, env *dto.Environtment) inf.IOidc {
+	refresh := env.OIDC.JWKS_REFRESH
+	if refresh <= 0 {
+		refresh = cons.OIDC_JWKS_REFRESH
+	}
+
+	leeway := env.OIDC.LEEWAY
+	if leeway <= 0 {
+		leeway = cons.OIDC_LEEWAY
+	}
+
+	return oidc{
+		ctx:     ctx,
+		env:     env,
+		refresh: time.Duration(time.Second * time.Duration(refresh)),
+		leeway:  time.Duration(time.Second * time.Duration(leeway)),
+	}
+}
+
+// Issued report whether iss claim of token is the configured issuer, the signature is not verified
+func (p oidc) Issued(token string) bool {
+	tokenMetadata, err := jwt.ParseString(token, jwt.WithVerify(false), jwt.WithValidate(false))
+	if err != nil {
+		return false
+	}
+
+	iss, ok := tokenMetadata.Issuer()
+
+	return ok && p.env.OIDC.ISSUER != "" && iss == p.env.OIDC.ISSUER
+}
+
+/**
+* Verify check token signature with the jwks key matching its kid, iss, aud, exp and nbf are validated with
+* OIDC_LEEWAY clock skew, role claim is mapped with OIDC_ROLES and unmapped identity is granted no permission
+ */
+func (p oidc) Verify(token string) (*opt.OidcMetadata, error) {
+	oidcMetadataRes := new(opt.OidcMetadata)
+
+	if p.env.OIDC.JWKS_URL == "" || p.env.OIDC.ISSUER == "" || p.env.OIDC.AUDIENCE == "" {
+		return nil, cons.OIDC_NOT_CONFIGURED
+	}
+
+	exportJws, err := jws.ParseString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := exportJws.Signatures()
+	if len(signatures) != 1 {
+		return nil, errors.New("Invalid signature")
+	}
+
+	kid, ok := signatures[0].ProtectedHeaders().KeyID()
+	if !ok || kid == "" {
+		return nil, errors.New("Invalid keyid")
+	}
+
+	jwkSet, err := p.keySet(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []jwt.ParseOption{
+		jwt.WithKeySet(jwkSet, jws.WithInferAlgorithmFromKey(true)),
+		jwt.WithIssuer(p.env.OIDC.ISSUER),
+		jwt.WithAudience(p.env.OIDC.AUDIENCE),
+		jwt.WithAcceptableSkew(p.leeway),
+		jwt.WithRequiredClaim(jwt.SubjectKey),
+		jwt.WithRequiredClaim(jwt.ExpirationKey),
+	}
+
+	tokenVerified, err := jwt.ParseString(token, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := make(map[string]any)
+
+	for _, name := range tokenVerified.Keys() {
+		var value any
+		if err := tokenVerified.Get(name, &value); err != nil {
+			return nil, err
+		}
+
+		claims[name] = value
+	}
+
+	roleClaim := p.env.OIDC.ROLE_CLAIM
+	if roleClaim == "" {
+		roleClaim = cons.OIDC_ROLE_CLAIM
+	}
+
+	tenantClaim := p.env.OIDC.TENANT_CLAIM
+	if tenantClaim == "" {
+		tenantClaim = cons.OIDC_TENANT_CLAIM
+	}
+
+	roles := []string{}
+	for _, value := range p.claimValues(claims, roleClaim) {
+		if role, ok := p.env.OIDC.ROLES[value]; ok {
+			roles = append(roles, role)
+		}
+	}
+
+	oidcMetadataRes.Subject, _ = tokenVerified.Subject()
+	oidcMetadataRes.Role = helper.Role(roles...)
+	oidcMetadataRes.Permissions = helper.Permissions(oidcMetadataRes.Role)
+
+	if tenants := p.claimValues(claims, tenantClaim); len(tenants) > 0 {
+		oidcMetadataRes.TenantID = tenants[0]
+	}
+
+	return oidcMetadataRes, nil
+}
+
+/**
+* keySet return the cached jwks, it is reloaded when the refresh interval is over or the kid is unknown after the
+* identity provider rotate its key, reload is throttled and the stale jwks is kept when the provider is unreachable
+ */
+func (p oidc) keySet(kid string) (jwk.Set, error) {
+	source := p.env.OIDC.JWKS_URL
+
+	value, _ := oidcKeySets.LoadOrStore(source, new(oidcKeySet))
+	keySet := value.(*oidcKeySet)
+
+	keySet.mutex.Lock()
+	defer keySet.mutex.Unlock()
+
+	found := false
+	if keySet.set != nil {
+		_, found = keySet.set.LookupKeyID(kid)
+	}
+
+	expired := keySet.set == nil || time.Since(keySet.fetchedAt) >= p.refresh
+	throttled := time.Since(keySet.checkedAt) < time.Duration(time.Second*cons.OIDC_JWKS_MIN_REFRESH)
+
+	if (expired || !found) && !throttled {
+		keySet.checkedAt = time.Now()
+
+		jwkSet, err := p.fetch(source)
+		if err != nil {
+			Logrus(cons.ERROR, err)
+			keySet.err = err
+		} else {
+			keySet.set = jwkSet
+			keySet.err = nil
+			keySet.fetchedAt = time.Now()
+		}
+	}
+
+	if keySet.set == nil {
+		if keySet.err != nil {
+			return nil, keySet.err
+		}
+
+		return nil, cons.OIDC_KEY_NOT_FOUND
+	}
+
+	if _, ok := keySet.set.LookupKeyID(kid); !ok {
+		return nil, cons.OIDC_KEY_NOT_FOUND
+	}
+
+	return keySet.set, nil
+}
+
+func (p oidc) fetch(source string) (jwk.Set, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		jwksByte, err := os.ReadFile(strings.TrimPrefix(source, "file://"))
+		if err != nil {
+			return nil, err
+		}
+
+		return jwk.Parse(jwksByte)
+	}
+
+	ctx, cancel := context.WithTimeout(p.ctx, time.Duration(time.Second*cons.OIDC_JWKS_TIMEOUT))
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc: fetch jwks %s return status %d", source, res.StatusCode)
+	}
+
+	jwksByte, err := io.ReadAll(io.LimitReader(res.Body, cons.OIDC_JWKS_MAX_SIZE))
+	if err != nil {
+		return nil, err
+	}
+
+	return jwk.Parse(jwksByte)
+}
+
+// claimValues resolve claim by name or dotted path like realm_access.roles, value can be string or list of string
+func (p oidc) claimValues(claims map[string]any, name string) []string {
+	var value any = claims
+
+	if claim, ok := claims[name]; ok {
+		value = claim
+	} else {
+		for _, segment := range strings.Split(name, ".") {
+			object, ok := value.(map[string]any)
+			if !ok {
+				return nil
+			}
+
+			if value, ok = object[segment]; !ok {
+				return nil
+			}
+		}
+	}
+
+	switch claim := value.(type) {
+
+	case string:
+		return []string{claim}
+
+	case []string:
+		return claim
+
+	case []any:
+		values := []string{}
+
+		for _, item := range claim {
+			if text, ok := item.(string); ok {
+				values = append(values, text)
+			}
+		}
+
+		return values
+
+	default:
+		return nil
+	}
+}
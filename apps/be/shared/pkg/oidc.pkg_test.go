@@ -0,0 +1,253 @@
+package pkg
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v3/jwa"
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/lestrrat-go/jwx/v3/jwt"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+const (
+	oidcTestIssuer   = "https://idp.example.com/realms/search"
+	oidcTestAudience = "go-fast-search"
+	oidcTestKeyID    = "test-key"
+)
+
+/**
+* oidcTestKey write the public jwks of a new rsa key to a local file, every test get its own file
+* so the jwks cache keyed by source is not shared between tests
+ */
+func oidcTestKey(t *testing.T) (jwk.Key, string) {
+	t.Helper()
+
+	rawKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey, err := jwk.Import(rawKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := privateKey.Set(jwk.KeyIDKey, oidcTestKeyID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := privateKey.Set(jwk.AlgorithmKey, jwa.RS256()); err != nil {
+		t.Fatal(err)
+	}
+
+	publicKey, err := privateKey.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwkSet := jwk.NewSet()
+	if err := jwkSet.AddKey(publicKey); err != nil {
+		t.Fatal(err)
+	}
+
+	jwksByte, err := json.Marshal(jwkSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(source, jwksByte, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return privateKey, source
+}
+
+func oidcTestEnv(source string) *dto.Environtment {
+	return &dto.Environtment{
+		OIDC: opt.Oidc{
+			JWKS_URL:     source,
+			ISSUER:       oidcTestIssuer,
+			AUDIENCE:     oidcTestAudience,
+			LEEWAY:       1,
+			ROLE_CLAIM:   "realm_access.roles",
+			ROLES:        map[string]string{"search-admin": cons.ROLE_ADMIN, "search-support": cons.ROLE_SUPPORT},
+			TENANT_CLAIM: "tenant",
+		},
+	}
+}
+
+func oidcTestToken(t *testing.T, key jwk.Key, claims map[string]any) string {
+	t.Helper()
+
+	token := jwt.New()
+
+	for name, value := range claims {
+		if err := token.Set(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tokenByte, err := jwt.Sign(token, jwt.WithKey(jwa.RS256(), key))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(tokenByte)
+}
+
+func oidcTestClaims(override map[string]any) map[string]any {
+	now := time.Now()
+
+	claims := map[string]any{
+		jwt.IssuerKey:     oidcTestIssuer,
+		jwt.AudienceKey:   []string{oidcTestAudience},
+		jwt.SubjectKey:    "user-1",
+		jwt.IssuedAtKey:   now,
+		jwt.NotBeforeKey:  now.Add(-time.Minute),
+		jwt.ExpirationKey: now.Add(time.Hour),
+		"realm_access":    map[string]any{"roles": []string{"offline_access", "search-admin"}},
+		"tenant":          "tenant-1",
+	}
+
+	for name, value := range override {
+		if value == nil {
+			delete(claims, name)
+			continue
+		}
+
+		claims[name] = value
+	}
+
+	return claims
+}
+
+func TestOidcVerify(t *testing.T) {
+	key, source := oidcTestKey(t)
+	oidc := NewOidc(context.Background(), oidcTestEnv(source))
+
+	oidcMetadata, err := oidc.Verify(oidcTestToken(t, key, oidcTestClaims(nil)))
+	if err != nil {
+		t.Fatalf("valid token is rejected: %v", err)
+	}
+
+	if oidcMetadata.Subject != "user-1" {
+		t.Errorf("subject = %q, want %q", oidcMetadata.Subject, "user-1")
+	}
+
+	if oidcMetadata.Role != cons.ROLE_ADMIN {
+		t.Errorf("role = %q, want %q", oidcMetadata.Role, cons.ROLE_ADMIN)
+	}
+
+	if !slices.Contains(oidcMetadata.Permissions, cons.PERMISSION_SEARCH_ADMIN) {
+		t.Errorf("permissions = %v, want %s", oidcMetadata.Permissions, cons.PERMISSION_SEARCH_ADMIN)
+	}
+
+	if oidcMetadata.TenantID != "tenant-1" {
+		t.Errorf("tenant = %q, want %q", oidcMetadata.TenantID, "tenant-1")
+	}
+}
+
+func TestOidcVerifyRejected(t *testing.T) {
+	key, source := oidcTestKey(t)
+	oidc := NewOidc(context.Background(), oidcTestEnv(source))
+
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		override map[string]any
+	}{
+		{name: "unknown issuer", override: map[string]any{jwt.IssuerKey: "https://idp.example.com/realms/other"}},
+		{name: "other audience", override: map[string]any{jwt.AudienceKey: []string{"other-client"}}},
+		{name: "missing audience", override: map[string]any{jwt.AudienceKey: nil}},
+		{name: "expired", override: map[string]any{jwt.ExpirationKey: now.Add(-time.Minute)}},
+		{name: "missing expiration", override: map[string]any{jwt.ExpirationKey: nil}},
+		{name: "not yet valid", override: map[string]any{jwt.NotBeforeKey: now.Add(time.Minute)}},
+		{name: "missing subject", override: map[string]any{jwt.SubjectKey: nil}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := oidc.Verify(oidcTestToken(t, key, oidcTestClaims(test.override))); err == nil {
+				t.Fatal("token is accepted")
+			}
+		})
+	}
+}
+
+func TestOidcVerifyUnknownKey(t *testing.T) {
+	_, source := oidcTestKey(t)
+	otherKey, _ := oidcTestKey(t)
+
+	oidc := NewOidc(context.Background(), oidcTestEnv(source))
+
+	if _, err := oidc.Verify(oidcTestToken(t, otherKey, oidcTestClaims(nil))); err == nil {
+		t.Fatal("token signed by another key is accepted")
+	}
+}
+
+func TestOidcVerifyRoleMapping(t *testing.T) {
+	key, source := oidcTestKey(t)
+	oidc := NewOidc(context.Background(), oidcTestEnv(source))
+
+	tests := []struct {
+		name   string
+		claims map[string]any
+		role   string
+		tenant string
+	}{
+		{name: "most privileged role", claims: map[string]any{"realm_access": map[string]any{"roles": []string{"search-support", "search-admin"}}}, role: cons.ROLE_ADMIN, tenant: "tenant-1"},
+		{name: "support role", claims: map[string]any{"realm_access": map[string]any{"roles": []string{"search-support"}}}, role: cons.ROLE_SUPPORT, tenant: "tenant-1"},
+		{name: "unmapped role", claims: map[string]any{"realm_access": map[string]any{"roles": []string{"offline_access"}}}, role: "", tenant: "tenant-1"},
+		{name: "missing role claim", claims: map[string]any{"realm_access": nil}, role: "", tenant: "tenant-1"},
+		{name: "tenant list", claims: map[string]any{"tenant": []string{"tenant-2", "tenant-3"}}, role: cons.ROLE_ADMIN, tenant: "tenant-2"},
+		{name: "missing tenant", claims: map[string]any{"tenant": nil}, role: cons.ROLE_ADMIN, tenant: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			oidcMetadata, err := oidc.Verify(oidcTestToken(t, key, oidcTestClaims(test.claims)))
+			if err != nil {
+				t.Fatalf("valid token is rejected: %v", err)
+			}
+
+			if oidcMetadata.Role != test.role {
+				t.Errorf("role = %q, want %q", oidcMetadata.Role, test.role)
+			}
+
+			if test.role == "" && len(oidcMetadata.Permissions) > 0 {
+				t.Errorf("permissions = %v, want none", oidcMetadata.Permissions)
+			}
+
+			if oidcMetadata.TenantID != test.tenant {
+				t.Errorf("tenant = %q, want %q", oidcMetadata.TenantID, test.tenant)
+			}
+		})
+	}
+}
+
+func TestOidcVerifyNotConfigured(t *testing.T) {
+	key, source := oidcTestKey(t)
+
+	env := oidcTestEnv(source)
+	env.OIDC.AUDIENCE = ""
+
+	oidc := NewOidc(context.Background(), env)
+
+	if _, err := oidc.Verify(oidcTestToken(t, key, oidcTestClaims(nil))); !errors.Is(err, cons.OIDC_NOT_CONFIGURED) {
+		t.Fatalf("error = %v, want %v", err, cons.OIDC_NOT_CONFIGURED)
+	}
+}
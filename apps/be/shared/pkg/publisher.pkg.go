@@ -0,0 +1,191 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	amqp "github.com/wagslane/go-rabbitmq"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+/**
+* Publisher pool per connection, rabbitmq.Publisher looks up the pool created for its connection
+* so every call site keeps using NewBroker without holding the pool itself
+ */
+var publishers sync.Map
+
+type publisher struct {
+	con        *amqp.Conn
+	publishers []*amqp.Publisher
+	timeout    time.Duration
+	next       *atomic.Uint64
+}
+
+func NewPublisher(con *amqp.Conn, env dto.Request[dto.Environtment]) (inf.IPublisher, error) {
+	options := dto.PublisherOptions{
+		Size:    env.Config.RABBITMQ.POOL_SIZE,
+		Timeout: time.Duration(time.Millisecond * time.Duration(env.Config.RABBITMQ.CONFIRM_TTL)),
+		Exchanges: []dto.RabbitExchangeOptions{
+			{Name: cons.EXCHANGE_NAME_SEARCH, Kind: cons.EXCHANGE_TYPE_DIRECT},
+			{Name: cons.EXCHANGE_NAME_DEAD_LETTER_QUEUE, Kind: cons.EXCHANGE_TYPE_DIRECT},
+			{Name: cons.EXCHANGE_NAME_RETRY, Kind: cons.EXCHANGE_TYPE_DIRECT},
+			{Name: cons.EXCHANGE_NAME_PARKING, Kind: cons.EXCHANGE_TYPE_DIRECT},
+		},
+	}
+
+	if options.Size < 1 {
+		options.Size = max(runtime.NumCPU()/2, 1)
+	}
+
+	if options.Timeout <= 0 {
+		options.Timeout = time.Duration(time.Second * 5)
+	}
+
+	if err := declareExchanges(con, options.Exchanges...); err != nil {
+		return nil, err
+	}
+
+	if err := declareTopology(env); err != nil {
+		return nil, err
+	}
+
+	p := &publisher{con: con, timeout: options.Timeout, next: new(atomic.Uint64)}
+
+	for range options.Size {
+		pub, err := amqp.NewPublisher(con, amqp.WithPublisherOptionsConfirm, amqp.WithPublisherOptionsLogging)
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+
+		p.publishers = append(p.publishers, pub)
+	}
+
+	publishers.Store(con, p)
+	return p, nil
+}
+
+/**
+* Declare exchanges once at startup with a short lived publisher, pooled publishers never declare
+ */
+func declareExchanges(con *amqp.Conn, exchanges ...dto.RabbitExchangeOptions) error {
+	for _, exchange := range exchanges {
+		pub, err := amqp.NewPublisher(con,
+			amqp.WithPublisherOptionsExchangeName(exchange.Name),
+			amqp.WithPublisherOptionsExchangeKind(exchange.Kind),
+			amqp.WithPublisherOptionsExchangeDeclare,
+			amqp.WithPublisherOptionsExchangeDurable,
+			amqp.WithPublisherOptionsLogging,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		pub.Close()
+	}
+
+	return nil
+}
+
+/**
+* Declare retry and parking topology of the search queues at startup, so parked message always
+* have a queue to land even before the first retry happen in this process
+ */
+func declareTopology(env dto.Request[dto.Environtment]) error {
+	retry := NewRetry(env)
+
+	queues := []string{cons.QUEUE_NAME_SEARCH}
+	for partition := range helper.Partitions(env.Config.RABBITMQ.PARTITIONS) {
+		queues = append(queues, fmt.Sprintf(cons.QUEUE_NAME_SEARCH_PARTITION, partition))
+	}
+
+	for _, queue := range queues {
+		if err := retry.Topology(cons.EXCHANGE_NAME_SEARCH, queue); err != nil {
+			return err
+		}
+
+		topologies.Store(queue, true)
+	}
+
+	return nil
+}
+
+func (p *publisher) Publish(ctx context.Context, req dto.Request[dto.BrokerOptions]) error {
+	parser := helper.NewParser()
+
+	if req.Option.ContentType == "" {
+		req.Option.ContentType = "application/json"
+	}
+
+	if req.Option.Timestamp.Sub(time.Now()).Seconds() < 1 {
+		req.Option.Timestamp = time.Now().Local()
+	}
+
+	bodyByte, err := parser.Marshal(&req.Option.Body)
+	if err != nil {
+		return err
+	}
+
+	publishOptions := []func(*amqp.PublishOptions){
+		amqp.WithPublishOptionsPersistentDelivery,
+		amqp.WithPublishOptionsExchange(req.Option.ExchangeName),
+		amqp.WithPublishOptionsContentType(req.Option.ContentType),
+		amqp.WithPublishOptionsTimestamp(req.Option.Timestamp),
+		amqp.WithPublishOptionsHeaders(amqp.Table(req.Option.Args)),
+	}
+
+	if req.Option.Expiration != "" {
+		publishOptions = append(publishOptions, amqp.WithPublishOptionsExpiration(req.Option.Expiration))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	pub := p.publishers[p.next.Add(1)%uint64(len(p.publishers))]
+
+	confirms, err := pub.PublishWithDeferredConfirmWithContext(ctx, bodyByte, []string{req.Option.QueueName}, publishOptions...)
+	if err != nil {
+		return err
+	}
+
+	for _, confirm := range confirms {
+		if confirm == nil {
+			continue
+		}
+
+		acked, err := confirm.WaitContext(ctx)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return cons.PUBLISH_CONFIRM_TIMEOUT
+			}
+
+			return err
+		}
+
+		if !acked {
+			Logrus(cons.ERROR, "Failed message delivery to: %s", req.Option.QueueName)
+			return cons.PUBLISH_NACKED
+		}
+	}
+
+	Logrus(cons.INFO, "Success message delivery to: %s", req.Option.QueueName)
+	return nil
+}
+
+func (p *publisher) Close() {
+	publishers.CompareAndDelete(p.con, p)
+
+	for _, pub := range p.publishers {
+		pub.Close()
+	}
+}
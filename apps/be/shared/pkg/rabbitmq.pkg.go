@@ -2,100 +2,106 @@ package pkg
 
 import (
 	"context"
-	"os"
-	"os/signal"
 	"runtime"
-	"syscall"
-	"time"
+	"sync"
 
 	"github.com/lithammer/shortuuid"
 	amqp "github.com/wagslane/go-rabbitmq"
 
 	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
 	"github.com/restuwahyu13/go-fast-search/shared/dto"
-	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
 	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
 )
 
+/**
+* Retry topology declared by this process, keyed by the origin queue
+ */
+var topologies sync.Map
+
 type rabbitmq struct {
 	ctx      context.Context
 	rabbitmq *amqp.Conn
+	retry    inf.IRetry
 }
 
-func NewRabbitMQ(ctx context.Context, con *amqp.Conn) inf.IRabbitMQ {
-	return rabbitmq{ctx: ctx, rabbitmq: con}
+func NewRabbitMQ(ctx context.Context, con *amqp.Conn, env dto.Request[dto.Environtment]) inf.IBroker {
+	return rabbitmq{ctx: ctx, rabbitmq: con, retry: NewRetry(env)}
 }
 
-func (p rabbitmq) Publisher(req dto.Request[dto.RabbitOptions]) error {
-	parser := helper.NewParser()
+func (p rabbitmq) Publisher(req dto.Request[dto.BrokerOptions]) error {
+	if p.rabbitmq == nil {
+		return cons.BROKER_NOT_CONNECTED
+	}
 
-	if req.Option.ContentType == "" {
-		req.Option.ContentType = "application/json"
+	publisher, ok := publishers.Load(p.rabbitmq)
+	if !ok {
+		return cons.PUBLISHER_NOT_FOUND
 	}
 
-	if req.Option.Timestamp.Sub(time.Now()).Seconds() < 1 {
-		req.Option.Timestamp = time.Now().Local()
+	return publisher.(inf.IPublisher).Publish(p.ctx, req)
+}
+
+func (p rabbitmq) Consumer(req dto.Request[dto.BrokerOptions], callback func(d dto.BrokerDelivery) (action dto.BrokerAction)) func() {
+	if p.rabbitmq == nil {
+		Logrus(cons.ERROR, cons.BROKER_NOT_CONNECTED)
+		return func() {}
 	}
 
-	publisher, err := amqp.NewPublisher(p.rabbitmq,
-		amqp.WithPublisherOptionsExchangeName(req.Option.ExchangeName),
-		amqp.WithPublisherOptionsExchangeKind(req.Option.ExchangeType),
-		amqp.WithPublisherOptionsExchangeDeclare,
-		amqp.WithPublisherOptionsExchangeDurable,
-		amqp.WithPublisherOptionsExchangeNoWait,
-		amqp.WithPublisherOptionsExchangeArgs(req.Option.Args),
-		amqp.WithPublisherOptionsLogging,
-	)
+	if req.Option.ConsumerID == "" {
+		req.Option.ConsumerID = shortuuid.New()
+	}
 
-	defer p.closeConnection(publisher, nil, p.rabbitmq)
-	if err != nil {
-		return err
+	if req.Option.Concurrency < 1 {
+		req.Option.Concurrency = runtime.NumCPU() / 2
 	}
 
-	bodyByte, err := parser.Marshal(&req.Option.Body)
-	if err != nil {
-		return err
+	if req.Option.Prefetch < 1 {
+		req.Option.Prefetch = 5
 	}
 
-	publisher.NotifyPublish(func(r amqp.Confirmation) {
-		if !r.Confirmation.Ack {
-			Logrus(cons.ERROR, "Failed message delivery to: %s", req.Option.QueueName)
-			return
-		}
+	handler := func(d amqp.Delivery) amqp.Action {
+		delivery := dto.BrokerDelivery{ID: d.MessageId, Headers: d.Headers, Body: d.Body}
+		delivery.Settle = func(action dto.BrokerAction) {
+			var err error
 
-		Logrus(cons.INFO, "Success message delivery to: %s", req.Option.QueueName)
-		return
-	})
+			switch action {
 
-	err = publisher.PublishWithContext(p.ctx, bodyByte, []string{req.Option.QueueName},
-		amqp.WithPublishOptionsPersistentDelivery,
-		amqp.WithPublishOptionsExchange(req.Option.ExchangeName),
-		amqp.WithPublishOptionsContentType(req.Option.ContentType),
-		amqp.WithPublishOptionsTimestamp(req.Option.Timestamp),
-		amqp.WithPublishOptionsHeaders(req.Option.Args),
-	)
+			case cons.BROKER_NACK_DISCARD:
+				err = d.Nack(false, false)
 
-	if err != nil {
-		return err
-	}
+			case cons.BROKER_NACK_REQUEUE:
+				err = d.Nack(false, true)
 
-	return nil
-}
+			default:
+				err = d.Ack(false)
+			}
 
-func (p rabbitmq) Consumer(req dto.Request[dto.RabbitOptions], callback func(d amqp.Delivery) (action amqp.Action)) {
-	if req.Option.ConsumerID == "" {
-		req.Option.ConsumerID = shortuuid.New()
-	}
+			if err != nil {
+				Logrus(cons.ERROR, err)
+			}
+		}
 
-	if req.Option.Concurrency < 1 {
-		req.Option.Concurrency = runtime.NumCPU() / 2
-	}
+		switch callback(delivery) {
 
-	if req.Option.Prefetch < 1 {
-		req.Option.Concurrency = 5
+		case cons.BROKER_NACK_DISCARD:
+			return amqp.NackDiscard
+
+		case cons.BROKER_NACK_REQUEUE:
+			return amqp.NackRequeue
+
+		case cons.BROKER_DEFER:
+			return amqp.Manual
+
+		default:
+			return amqp.Ack
+		}
 	}
 
-	consumer, err := amqp.NewConsumer(p.rabbitmq, callback, req.Option.QueueName,
+	/**
+	* Stop close the consumer channel, rabbitmq stop pushing message and requeue every unacked delivery,
+	* the ack of a delivery still in flight is then lost and its redelivery is dropped as duplicate
+	 */
+	consumer, err := amqp.NewConsumer(p.rabbitmq, handler, req.Option.QueueName,
 		amqp.WithConsumerOptionsExchangeName(req.Option.ExchangeName),
 		amqp.WithConsumerOptionsExchangeKind(req.Option.ExchangeType),
 		amqp.WithConsumerOptionsBinding(amqp.Binding{
@@ -103,7 +109,7 @@ func (p rabbitmq) Consumer(req dto.Request[dto.RabbitOptions], callback func(d a
 			BindingOptions: amqp.BindingOptions{
 				Declare: true,
 				NoWait:  true,
-				Args:    req.Option.Args,
+				Args:    amqp.Table(req.Option.Args),
 			},
 		}),
 		amqp.WithConsumerOptionsExchangeDurable,
@@ -112,44 +118,36 @@ func (p rabbitmq) Consumer(req dto.Request[dto.RabbitOptions], callback func(d a
 		amqp.WithConsumerOptionsConsumerAutoAck(req.Option.Ack),
 		amqp.WithConsumerOptionsConcurrency(req.Option.Concurrency),
 		amqp.WithConsumerOptionsQOSPrefetch(req.Option.Prefetch),
-		amqp.WithConsumerOptionsQueueArgs(req.Option.Args),
+		amqp.WithConsumerOptionsQueueArgs(amqp.Table(req.Option.Args)),
 		amqp.WithConsumerOptionsLogging,
 	)
-	defer p.closeConnection(nil, consumer, p.rabbitmq)
 
 	if err != nil {
 		Logrus(cons.ERROR, err)
-		return
+		return func() {}
 	}
 
-	return
+	return sync.OnceFunc(consumer.Close)
 }
 
-func (h *rabbitmq) closeConnection(publisher *amqp.Publisher, consumer *amqp.Consumer, connection *amqp.Conn) {
-	defer h.recovery()
-
-	closeChan := make(chan os.Signal, 1)
-	signal.Notify(closeChan, syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP, syscall.SIGQUIT, syscall.SIGALRM, syscall.SIGABRT, syscall.SIGUSR1)
-
-	go func() {
-		<-closeChan
-
-		if consumer != nil {
-			consumer.Close()
+/**
+* Delay route the message through the per attempt delay queue, the queue dead letter it back
+* to the origin exchange and queue once the ttl is elapsed
+ */
+func (p rabbitmq) Delay(req dto.Request[dto.BrokerOptions], attempt int) error {
+	if _, ok := topologies.Load(req.Option.QueueName); !ok {
+		if err := p.retry.Topology(req.Option.ExchangeName, req.Option.QueueName); err != nil {
+			return err
 		}
 
-		if publisher != nil {
-			publisher.Close()
-		}
+		topologies.Store(req.Option.QueueName, true)
+	}
 
-		if connection != nil {
-			connection.Close()
-		}
-	}()
-}
+	delay_req := req
+	delay_req.Option.ExchangeName = cons.EXCHANGE_NAME_RETRY
+	delay_req.Option.ExchangeType = cons.EXCHANGE_TYPE_DIRECT
+	delay_req.Option.QueueName = p.retry.Queue(req.Option.QueueName, attempt)
+	delay_req.Option.Expiration = p.retry.Expiration(attempt)
 
-func (p rabbitmq) recovery() {
-	if err := recover(); err != nil {
-		return
-	}
+	return p.Publisher(delay_req)
 }
@@ -0,0 +1,146 @@
+package pkg
+
+import (
+	"crypto/tls"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	amqp091 "github.com/rabbitmq/amqp091-go"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+type retry struct {
+	env      dto.Request[dto.Environtment]
+	max      int
+	delay    time.Duration
+	maxDelay time.Duration
+}
+
+func NewRetry(env dto.Request[dto.Environtment]) inf.IRetry {
+	options := dto.RetryOptions{
+		Max:   env.Config.RABBITMQ.RETRY_MAX,
+		Delay: time.Duration(time.Millisecond * time.Duration(env.Config.RABBITMQ.RETRY_DELAY)),
+	}
+
+	if options.Max < 1 {
+		options.Max = 5
+	}
+
+	if options.Delay <= 0 {
+		options.Delay = time.Duration(time.Second * 5)
+	}
+
+	return retry{env: env, max: options.Max, delay: options.Delay, maxDelay: time.Duration(time.Hour * 1)}
+}
+
+/**
+* Declare one delay queue per attempt, each queue have fixed ttl and dead letter back to the origin queue,
+* parking queue is terminal queue for message which exceed max attempt
+ */
+func (p retry) Topology(exchange, queue string) error {
+	con, err := amqp091.DialConfig(p.env.Config.RABBITMQ.URL, amqp091.Config{
+		Vhost:           p.env.Config.RABBITMQ.VSN,
+		Heartbeat:       time.Duration(time.Second * 5),
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: p.env.Config.RABBITMQ.TLS_SKIP_VERIFY},
+	})
+
+	if err != nil {
+		return err
+	}
+	defer con.Close()
+
+	ch, err := con.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	if err := ch.ExchangeDeclare(cons.EXCHANGE_NAME_RETRY, cons.EXCHANGE_TYPE_DIRECT, true, false, false, false, nil); err != nil {
+		return err
+	}
+
+	for attempt := 1; attempt <= p.max; attempt++ {
+		name := p.Queue(queue, attempt)
+
+		_, err := ch.QueueDeclare(name, true, false, false, false, amqp091.Table{
+			cons.X_MESSAGE_TTL:          p.Delay(attempt).Milliseconds(),
+			cons.X_DEAD_LETTER_EXCHANGE: exchange,
+			cons.X_DEAD_LETTER_ROUTING:  queue,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if err := ch.QueueBind(name, name, cons.EXCHANGE_NAME_RETRY, false, nil); err != nil {
+			return err
+		}
+	}
+
+	if err := ch.ExchangeDeclare(cons.EXCHANGE_NAME_PARKING, cons.EXCHANGE_TYPE_DIRECT, true, false, false, false, nil); err != nil {
+		return err
+	}
+
+	if _, err := ch.QueueDeclare(cons.QUEUE_NAME_PARKING, true, false, false, false, nil); err != nil {
+		return err
+	}
+
+	return ch.QueueBind(cons.QUEUE_NAME_PARKING, cons.QUEUE_NAME_PARKING, cons.EXCHANGE_NAME_PARKING, false, nil)
+}
+
+func (p retry) Attempt(headers map[string]any) int {
+	switch attempt := headers[cons.X_RETRY_ATTEMPT].(type) {
+
+	case int:
+		return attempt
+
+	case int32:
+		return int(attempt)
+
+	case int64:
+		return int(attempt)
+
+	case float64:
+		return int(attempt)
+
+	case string:
+		value, _ := strconv.Atoi(attempt)
+		return value
+
+	default:
+		return 0
+	}
+}
+
+func (p retry) Exhausted(attempt int) bool {
+	return attempt > p.max
+}
+
+func (p retry) Queue(queue string, attempt int) string {
+	return fmt.Sprintf(cons.QUEUE_NAME_RETRY, queue, attempt)
+}
+
+func (p retry) Delay(attempt int) time.Duration {
+	delay := p.delay
+
+	for i := 1; i < attempt && delay < p.maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, p.maxDelay)
+}
+
+/**
+* Per message expiration take up to 20% jitter from the queue ttl, so retried message not arrive at the same time
+ */
+func (p retry) Expiration(attempt int) string {
+	delay := p.Delay(attempt)
+	jitter := time.Duration(rand.Int64N(int64(delay/5) + 1))
+
+	return strconv.FormatInt((delay - jitter).Milliseconds(), 10)
+}
@@ -0,0 +1,226 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lithammer/shortuuid"
+	goredis "github.com/redis/go-redis/v9"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+/**
+* KEYS[1] = session key
+* ARGV[1] = current refresh id, ARGV[2] = next refresh id, ARGV[3] = ttl in milliseconds
+ */
+var sessionRotateScript = goredis.NewScript(`
+if redis.call('HGET', KEYS[1], 'refresh_id') == ARGV[1] then
+	redis.call('HSET', KEYS[1], 'refresh_id', ARGV[2])
+	redis.call('PEXPIRE', KEYS[1], ARGV[3])
+	return 1
+end
+
+return 0
+`)
+
+type session struct {
+	ctx     context.Context
+	redis   *goredis.Client
+	access  inf.IJsonWebToken
+	refresh inf.IJsonWebToken
+	ttl     time.Duration
+}
+
+/**
+* Session group access token and rotating refresh token of one login into a family stored in redis,
+* access token is signed with prefix subject:session and refresh token with prefix REFRESH:subject:session:refresh_id
+ */
+func NewSession(ctx context.Context, env *dto.Environtment, con *goredis.Client) inf.ISession {
+	refreshEnv := *env
+
+	if refreshEnv.JWT.EXPIRED = env.JWT.REFRESH_EXPIRED; refreshEnv.JWT.EXPIRED <= 0 {
+		refreshEnv.JWT.EXPIRED = cons.AUTH_REFRESH_EXPIRED
+	}
+
+	return session{
+		ctx:     ctx,
+		redis:   con,
+		access:  NewJsonWebToken(ctx, env, con),
+		refresh: NewJsonWebToken(ctx, &refreshEnv, con),
+		ttl:     time.Duration(time.Minute * time.Duration(refreshEnv.JWT.EXPIRED)),
+	}
+}
+
+func (p session) Create(subject string, claim any) (*opt.SessionMetadata, error) {
+	sessionID := uuid.NewString()
+	refreshID := shortuuid.New()
+
+	claimByte, err := json.Marshal(claim)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionKey := fmt.Sprintf(cons.AUTH_SESSION_KEY, sessionID)
+	subjectKey := fmt.Sprintf(cons.AUTH_SUBJECT_SESSIONS_KEY, subject)
+
+	_, err = p.redis.TxPipelined(p.ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(p.ctx, sessionKey, "subject", subject, "refresh_id", refreshID, "claim", string(claimByte), "created_at", time.Now().Format(time.RFC3339))
+		pipe.PExpire(p.ctx, sessionKey, p.ttl)
+		pipe.SAdd(p.ctx, subjectKey, sessionID)
+		pipe.PExpire(p.ctx, subjectKey, p.ttl)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return p.sign(subject, sessionID, refreshID, claim)
+}
+
+/**
+* Refresh rotate the refresh token, presenting a revoked or already rotated refresh token is treated as reuse
+* and revoke the whole session, so a stolen refresh token is only usable until the legitimate client refresh
+ */
+func (p session) Refresh(token string) (*opt.SessionMetadata, error) {
+	verifyMetadata, err := p.refresh.Verify(token)
+	if err != nil && !errors.Is(err, cons.AUTH_TOKEN_REVOKED) {
+		return nil, err
+	}
+
+	segments := strings.Split(verifyMetadata.Prefix, ":")
+	if len(segments) != 4 || fmt.Sprintf(cons.AUTH_REFRESH_PREFIX, segments[1], segments[2], segments[3]) != verifyMetadata.Prefix {
+		return nil, cons.AUTH_TOKEN_INVALID
+	}
+
+	subject, sessionID, refreshID := segments[1], segments[2], segments[3]
+
+	if err != nil {
+		if err := p.Revoke(subject, sessionID); err != nil {
+			return nil, err
+		}
+
+		return nil, cons.AUTH_TOKEN_REUSED
+	}
+
+	sessionKey := fmt.Sprintf(cons.AUTH_SESSION_KEY, sessionID)
+	nextRefreshID := shortuuid.New()
+
+	rotated, err := sessionRotateScript.Run(p.ctx, p.redis, []string{sessionKey}, refreshID, nextRefreshID, p.ttl.Milliseconds()).Int()
+	if err != nil {
+		return nil, err
+	}
+
+	if rotated < 1 {
+		if err := p.Revoke(subject, sessionID); err != nil {
+			return nil, err
+		}
+
+		return nil, cons.AUTH_TOKEN_REUSED
+	}
+
+	sessionMetadata, err := p.redis.HMGet(p.ctx, sessionKey, "subject", "claim").Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if owner, _ := sessionMetadata[0].(string); owner != subject {
+		return nil, cons.AUTH_TOKEN_INVALID
+	}
+
+	claim := make(map[string]any)
+	if value, _ := sessionMetadata[1].(string); value != "" {
+		if err := json.Unmarshal([]byte(value), &claim); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.refresh.Revoke(verifyMetadata.Prefix); err != nil {
+		return nil, err
+	}
+
+	if err := p.access.Revoke(fmt.Sprintf(cons.AUTH_ACCESS_PREFIX, subject, sessionID)); err != nil {
+		return nil, err
+	}
+
+	if err := p.redis.PExpire(p.ctx, fmt.Sprintf(cons.AUTH_SUBJECT_SESSIONS_KEY, subject), p.ttl).Err(); err != nil {
+		return nil, err
+	}
+
+	return p.sign(subject, sessionID, nextRefreshID, claim)
+}
+
+func (p session) Revoke(subject, sessionID string) error {
+	sessionKey := fmt.Sprintf(cons.AUTH_SESSION_KEY, sessionID)
+
+	refreshID, err := p.redis.HGet(p.ctx, sessionKey, "refresh_id").Result()
+	if err != nil && !errors.Is(err, goredis.Nil) {
+		return err
+	}
+
+	if err := p.access.Revoke(fmt.Sprintf(cons.AUTH_ACCESS_PREFIX, subject, sessionID)); err != nil {
+		return err
+	}
+
+	if refreshID != "" {
+		if err := p.refresh.Revoke(fmt.Sprintf(cons.AUTH_REFRESH_PREFIX, subject, sessionID, refreshID)); err != nil {
+			return err
+		}
+	}
+
+	_, err = p.redis.TxPipelined(p.ctx, func(pipe goredis.Pipeliner) error {
+		pipe.Del(p.ctx, sessionKey)
+		pipe.SRem(p.ctx, fmt.Sprintf(cons.AUTH_SUBJECT_SESSIONS_KEY, subject), sessionID)
+
+		return nil
+	})
+
+	return err
+}
+
+func (p session) RevokeAll(subject string) (int, error) {
+	sessionIDs, err := p.redis.SMembers(p.ctx, fmt.Sprintf(cons.AUTH_SUBJECT_SESSIONS_KEY, subject)).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, sessionID := range sessionIDs {
+		if err := p.Revoke(subject, sessionID); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(sessionIDs), nil
+}
+
+func (p session) sign(subject, sessionID, refreshID string, claim any) (*opt.SessionMetadata, error) {
+	accessToken, err := p.access.Sign(fmt.Sprintf(cons.AUTH_ACCESS_PREFIX, subject, sessionID), claim)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := p.refresh.Sign(fmt.Sprintf(cons.AUTH_REFRESH_PREFIX, subject, sessionID, refreshID), claim)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionMetadataRes := new(opt.SessionMetadata)
+	sessionMetadataRes.Subject = subject
+	sessionMetadataRes.Session = sessionID
+	sessionMetadataRes.Token.AccessToken = accessToken.Token
+	sessionMetadataRes.Token.RefreshToken = refreshToken.Token
+	sessionMetadataRes.Token.Expired = accessToken.Expired
+	sessionMetadataRes.Token.RefreshExpired = refreshToken.Expired
+
+	return sessionMetadataRes, nil
+}
@@ -0,0 +1,144 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	cons "github.com/restuwahyu13/go-fast-search/shared/constants"
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	helper "github.com/restuwahyu13/go-fast-search/shared/helpers"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+)
+
+/**
+* Headers which change how the message is handled, signed together with the body
+ */
+var signedHeaders = []string{cons.X_RETRY_ATTEMPT, cons.X_DEAD_LETTER_ID, cons.X_RABBIT_EXCHANGE, cons.X_RABBIT_QUEUE}
+
+type signer struct {
+	crypto inf.ICrypto
+	keyID  string
+	keys   map[string]string
+	ttl    time.Duration
+}
+
+/**
+* Without BROKER_SIGNING_KEYS the rabbitmq secret become the only key, the dedup ttl is never shorter than
+* the signature ttl so a replay inside the window is dropped as duplicate and outside it as expired
+ */
+func NewSigner(env dto.Request[dto.Environtment]) inf.ISigner {
+	keyID := env.Config.BROKER.SIGNING_KEY_ID
+	keys := env.Config.BROKER.SIGNING_KEYS
+	ttl := time.Duration(time.Second * time.Duration(env.Config.BROKER.SIGNATURE_TTL))
+
+	if len(keys) < 1 {
+		keys = map[string]string{cons.SIGNATURE_DEFAULT_KEY_ID: env.Config.RABBITMQ.SECRET}
+	}
+
+	if keyID == "" {
+		keyID = cons.SIGNATURE_DEFAULT_KEY_ID
+	}
+
+	if ttl <= 0 {
+		ttl = time.Duration(time.Second * cons.SIGNATURE_DEFAULT_TTL)
+	}
+
+	return signer{crypto: helper.NewCrypto(), keyID: keyID, keys: keys, ttl: ttl}
+}
+
+/**
+* Body is marshaled once here and passed as raw message, so the backend publish the exact signed bytes,
+* delay push the expiry forward for message held by the broker before it is delivered
+ */
+func (p signer) Sign(req *dto.Request[dto.BrokerOptions], delay time.Duration) error {
+	parser := helper.NewParser()
+
+	key, ok := p.keys[p.keyID]
+	if !ok {
+		return fmt.Errorf("%w: %s", cons.SIGNATURE_UNKNOWN_KEY, p.keyID)
+	}
+
+	bodyByte, err := parser.Marshal(&req.Option.Body)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	req.Option.Body = json.RawMessage(bodyByte)
+	req.Option.Args = maps.Clone(req.Option.Args)
+
+	if req.Option.Args == nil {
+		req.Option.Args = make(map[string]any)
+	}
+
+	req.Option.Args[cons.X_SIGNATURE_KEY_ID] = p.keyID
+	req.Option.Args[cons.X_SIGNATURE_TIMESTAMP] = strconv.FormatInt(now.UnixMilli(), 10)
+	req.Option.Args[cons.X_SIGNATURE_EXPIRES] = strconv.FormatInt(now.Add(delay+p.ttl).UnixMilli(), 10)
+
+	signature, err := p.crypto.HMACSHA512Sign(key, p.data(req.Option.Args, bodyByte))
+	if err != nil {
+		return err
+	}
+
+	req.Option.Args[cons.X_SIGNATURE] = signature
+	return nil
+}
+
+func (p signer) Verify(d dto.BrokerDelivery) error {
+	signature, _ := d.Headers[cons.X_SIGNATURE].(string)
+	if signature == "" {
+		return cons.SIGNATURE_MISSING
+	}
+
+	keyID, _ := d.Headers[cons.X_SIGNATURE_KEY_ID].(string)
+
+	key, ok := p.keys[keyID]
+	if !ok {
+		return fmt.Errorf("%w: %s", cons.SIGNATURE_UNKNOWN_KEY, keyID)
+	}
+
+	if !p.crypto.HMACSHA512Verify(key, p.data(d.Headers, d.Body), signature) {
+		return cons.SIGNATURE_INVALID
+	}
+
+	timestamp, err := strconv.ParseInt(fmt.Sprint(d.Headers[cons.X_SIGNATURE_TIMESTAMP]), 10, 64)
+	if err != nil {
+		return cons.SIGNATURE_INVALID
+	}
+
+	expires, err := strconv.ParseInt(fmt.Sprint(d.Headers[cons.X_SIGNATURE_EXPIRES]), 10, 64)
+	if err != nil {
+		return cons.SIGNATURE_INVALID
+	}
+
+	now := time.Now()
+
+	if time.UnixMilli(timestamp).After(now.Add(time.Minute)) {
+		return cons.SIGNATURE_INVALID
+	}
+
+	if now.After(time.UnixMilli(expires)) {
+		return cons.SIGNATURE_EXPIRED
+	}
+
+	return nil
+}
+
+func (p signer) data(headers map[string]any, body []byte) string {
+	fields := []string{
+		fmt.Sprint(headers[cons.X_SIGNATURE_KEY_ID]),
+		fmt.Sprint(headers[cons.X_SIGNATURE_TIMESTAMP]),
+		fmt.Sprint(headers[cons.X_SIGNATURE_EXPIRES]),
+	}
+
+	for _, header := range signedHeaders {
+		fields = append(fields, fmt.Sprint(headers[header]))
+	}
+
+	return strings.Join(append(fields, string(body)), "\n")
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type apiKeysUsecase struct {
+	service inf.IApiKeysService
+}
+
+func NewApiKeysUsecase(options dto.UsecaseOptions[inf.IApiKeysService]) inf.IApiKeysUsecase {
+	return apiKeysUsecase{service: options.SERVICE}
+}
+
+func (u apiKeysUsecase) CreateApiKey(ctx context.Context, req dto.Request[dto.CreateApiKeyDTO]) opt.Response {
+	return u.service.CreateApiKey(ctx, req)
+}
+
+func (u apiKeysUsecase) FindAllApiKeys(ctx context.Context, req dto.Request[dto.ListApiKeysDTO]) opt.Response {
+	return u.service.FindAllApiKeys(ctx, req)
+}
+
+func (u apiKeysUsecase) RotateApiKey(ctx context.Context, req dto.Request[dto.ApiKeyIDDTO]) opt.Response {
+	return u.service.RotateApiKey(ctx, req)
+}
+
+func (u apiKeysUsecase) RevokeApiKey(ctx context.Context, req dto.Request[dto.ApiKeyIDDTO]) opt.Response {
+	return u.service.RevokeApiKey(ctx, req)
+}
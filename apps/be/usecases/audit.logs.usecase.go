@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type auditLogsUsecase struct {
+	service inf.IAuditLogsService
+}
+
+func NewAuditLogsUsecase(options dto.UsecaseOptions[inf.IAuditLogsService]) inf.IAuditLogsUsecase {
+	return auditLogsUsecase{service: options.SERVICE}
+}
+
+func (u auditLogsUsecase) FindAllAuditLogs(ctx context.Context, req dto.Request[dto.ListAuditLogsDTO]) opt.Response {
+	return u.service.FindAllAuditLogs(ctx, req)
+}
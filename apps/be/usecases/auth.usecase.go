@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type authUsecase struct {
+	service inf.IAuthService
+}
+
+func NewAuthUsecase(options dto.UsecaseOptions[inf.IAuthService]) inf.IAuthUsecase {
+	return authUsecase{service: options.SERVICE}
+}
+
+func (u authUsecase) Login(ctx context.Context, req dto.Request[dto.LoginDTO]) opt.Response {
+	return u.service.Login(ctx, req)
+}
+
+func (u authUsecase) Refresh(ctx context.Context, req dto.Request[dto.RefreshTokenDTO]) opt.Response {
+	return u.service.Refresh(ctx, req)
+}
+
+func (u authUsecase) Logout(ctx context.Context, req dto.Request[dto.LogoutDTO]) opt.Response {
+	return u.service.Logout(ctx, req)
+}
+
+func (u authUsecase) Jwks(ctx context.Context) opt.Response {
+	return u.service.Jwks(ctx)
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type deadLettersUsecase struct {
+	service inf.IDeadLettersService
+}
+
+func NewDeadLettersUsecase(options dto.UsecaseOptions[inf.IDeadLettersService]) inf.IDeadLettersUsecase {
+	return deadLettersUsecase{service: options.SERVICE}
+}
+
+func (u deadLettersUsecase) FindAllDeadLetters(ctx context.Context, req dto.Request[dto.ListDeadLettersDTO]) opt.Response {
+	return u.service.FindAllDeadLetters(ctx, req)
+}
+
+func (u deadLettersUsecase) FindDeadLetter(ctx context.Context, req dto.Request[dto.DeadLetterIDDTO]) opt.Response {
+	return u.service.FindDeadLetter(ctx, req)
+}
+
+func (u deadLettersUsecase) ReplayDeadLetter(ctx context.Context, req dto.Request[dto.DeadLetterIDDTO]) opt.Response {
+	return u.service.ReplayDeadLetter(ctx, req)
+}
+
+func (u deadLettersUsecase) ReplayAllDeadLetters(ctx context.Context, req dto.Request[dto.DeadLettersFilterDTO]) opt.Response {
+	return u.service.ReplayAllDeadLetters(ctx, req)
+}
+
+func (u deadLettersUsecase) DiscardDeadLetter(ctx context.Context, req dto.Request[dto.DeadLetterIDDTO]) opt.Response {
+	return u.service.DiscardDeadLetter(ctx, req)
+}
+
+func (u deadLettersUsecase) DiscardAllDeadLetters(ctx context.Context, req dto.Request[dto.DeadLettersFilterDTO]) opt.Response {
+	return u.service.DiscardAllDeadLetters(ctx, req)
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type jobsUsecase struct {
+	service inf.IJobsService
+}
+
+func NewJobsUsecase(options dto.UsecaseOptions[inf.IJobsService]) inf.IJobsUsecase {
+	return jobsUsecase{service: options.SERVICE}
+}
+
+func (u jobsUsecase) FindAllJobs(ctx context.Context) opt.Response {
+	return u.service.FindAllJobs(ctx)
+}
+
+func (u jobsUsecase) FindAllJobRuns(ctx context.Context) opt.Response {
+	return u.service.FindAllJobRuns(ctx)
+}
+
+func (u jobsUsecase) TriggerJob(ctx context.Context, req dto.Request[dto.JobNameDTO]) opt.Response {
+	return u.service.TriggerJob(ctx, req)
+}
+
+func (u jobsUsecase) PauseJob(ctx context.Context, req dto.Request[dto.JobNameDTO]) opt.Response {
+	return u.service.PauseJob(ctx, req)
+}
+
+func (u jobsUsecase) ResumeJob(ctx context.Context, req dto.Request[dto.JobNameDTO]) opt.Response {
+	return u.service.ResumeJob(ctx, req)
+}
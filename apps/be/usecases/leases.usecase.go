@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/restuwahyu13/go-fast-search/shared/dto"
+	inf "github.com/restuwahyu13/go-fast-search/shared/interfaces"
+	opt "github.com/restuwahyu13/go-fast-search/shared/output"
+)
+
+type leasesUsecase struct {
+	service inf.ILeasesService
+}
+
+func NewLeasesUsecase(options dto.UsecaseOptions[inf.ILeasesService]) inf.ILeasesUsecase {
+	return leasesUsecase{service: options.SERVICE}
+}
+
+func (u leasesUsecase) FindAllLeases(ctx context.Context) opt.Response {
+	return u.service.FindAllLeases(ctx)
+}
+
+func (u leasesUsecase) FindLease(ctx context.Context, req dto.Request[dto.FindLeaseDTO]) opt.Response {
+	return u.service.FindLease(ctx, req)
+}